@@ -8,11 +8,14 @@ import (
 
 	"github.com/Muh-Sidik/kasir-api/config"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
 )
 
 func New(e *config.Env) *sql.DB {
 
-	db, err := sql.Open("pgx", e.DB_URL)
+	_, driver, dsn := ParseURL(e.DB_URL)
+
+	db, err := sql.Open(driver, dsn)
 
 	if err != nil {
 		log.Fatalf("error open database: %v", err)
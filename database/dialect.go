@@ -0,0 +1,104 @@
+package database
+
+import (
+	"net/url"
+	"strings"
+)
+
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// sqliteDefaults dipakai jika DB_URL sqlite tidak menentukan sendiri:
+// foreign key aktif, tunggu lock sampai 5 detik, WAL supaya baca tidak
+// memblokir tulis, dan transaksi langsung mengambil write lock (BEGIN IMMEDIATE)
+// sebagai pengganti SELECT ... FOR UPDATE
+var sqliteDefaults = map[string][]string{
+	"_pragma":      {"foreign_keys(1)", "busy_timeout(5000)", "journal_mode(WAL)"},
+	"_time_format": {"sqlite"},
+	"_txlock":      {"immediate"},
+}
+
+// ParseURL menentukan dialect, nama driver dan DSN dari DB_URL.
+// Contoh sqlite: "sqlite://kasir.db", "sqlite:///var/lib/kasir/kasir.db" atau "file:kasir.db"
+func ParseURL(dbURL string) (Dialect, string, string) {
+	var path string
+	switch {
+	case strings.HasPrefix(dbURL, "sqlite://"):
+		path = strings.TrimPrefix(dbURL, "sqlite://")
+	case strings.HasPrefix(dbURL, "sqlite:"):
+		path = strings.TrimPrefix(dbURL, "sqlite:")
+	case strings.HasPrefix(dbURL, "file:"):
+		path = strings.TrimPrefix(dbURL, "file:")
+	default:
+		return Postgres, "pgx", dbURL
+	}
+
+	path, rawQuery, _ := strings.Cut(path, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		query = url.Values{}
+	}
+
+	for key, values := range sqliteDefaults {
+		if key == "_pragma" {
+			for _, value := range values {
+				if !hasPragma(query["_pragma"], value) {
+					query.Add(key, value)
+				}
+			}
+			continue
+		}
+
+		if !query.Has(key) {
+			query[key] = values
+		}
+	}
+
+	return SQLite, "sqlite", "file:" + path + "?" + query.Encode()
+}
+
+// hasPragma mengecek apakah pragma dengan nama yang sama sudah diset, contoh "busy_timeout(1000)"
+func hasPragma(pragmas []string, pragma string) bool {
+	name, _, _ := strings.Cut(pragma, "(")
+	for _, p := range pragmas {
+		if strings.EqualFold(strings.TrimSpace(strings.SplitN(p, "(", 2)[0]), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Now adalah ekspresi waktu sekarang. Di sqlite waktu disimpan sebagai teks UTC
+// dengan format yang sama seperti parameter time.Time dari driver supaya bisa dibandingkan
+func (d Dialect) Now() string {
+	if d == SQLite {
+		return "strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')"
+	}
+
+	return "NOW()"
+}
+
+// ForUpdate mengunci baris yang dibaca sampai transaksi selesai. Sqlite tidak punya
+// row lock, transaksinya sudah memegang write lock sejak BEGIN (lihat _txlock)
+func (d Dialect) ForUpdate(tables ...string) string {
+	if d == SQLite {
+		return ""
+	}
+
+	if len(tables) == 0 {
+		return "FOR UPDATE"
+	}
+
+	return "FOR UPDATE OF " + strings.Join(tables, ", ")
+}
+
+// DialectOf mengembalikan dialect dari DB_URL
+func DialectOf(dbURL string) Dialect {
+	dialect, _, _ := ParseURL(dbURL)
+	return dialect
+}
@@ -0,0 +1,24 @@
+-- categories dan product sudah ada sebelum migrasi berversi. CREATE hanya berisi skema awal,
+-- kolom baru ditambah dengan ALTER supaya database lama ikut ter-upgrade
+CREATE TABLE IF NOT EXISTS categories (
+    id          UUID PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS product (
+    id          UUID PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    price       INTEGER NOT NULL CHECK (price >= 0),
+    stock       INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    category_id UUID NOT NULL REFERENCES categories (id),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_category_id ON product (category_id);
+CREATE INDEX IF NOT EXISTS idx_product_created_at ON product (created_at);
+
+ALTER TABLE categories ADD COLUMN tax_exempt BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS promotions (
+    id              UUID PRIMARY KEY,
+    name            VARCHAR(255) NOT NULL,
+    type            VARCHAR(32) NOT NULL,
+    code            VARCHAR(50) UNIQUE,
+    product_id      UUID REFERENCES product (id),
+    value           BIGINT NOT NULL DEFAULT 0,
+    buy_quantity    INTEGER NOT NULL DEFAULT 0,
+    get_quantity    INTEGER NOT NULL DEFAULT 0,
+    bundle_quantity INTEGER NOT NULL DEFAULT 0,
+    bundle_price    BIGINT NOT NULL DEFAULT 0,
+    min_purchase    BIGINT NOT NULL DEFAULT 0,
+    max_discount    BIGINT NOT NULL DEFAULT 0,
+    start_at        TIMESTAMPTZ NOT NULL,
+    end_at          TIMESTAMPTZ NOT NULL,
+    is_active       BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotions_product_id ON promotions (product_id);
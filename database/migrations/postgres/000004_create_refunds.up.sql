@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS refunds (
+    id             UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES transactions (id),
+    type           VARCHAR(16) NOT NULL,
+    reason         VARCHAR(255) NOT NULL,
+    total_amount   BIGINT NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_transaction_id ON refunds (transaction_id);
+
+CREATE TABLE IF NOT EXISTS refund_details (
+    id                    UUID PRIMARY KEY,
+    refund_id             UUID NOT NULL REFERENCES refunds (id),
+    transaction_detail_id UUID NOT NULL REFERENCES transaction_details (id),
+    product_id            UUID NOT NULL REFERENCES product (id),
+    quantity              INTEGER NOT NULL CHECK (quantity > 0),
+    amount                BIGINT NOT NULL,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refund_details_refund_id ON refund_details (refund_id);
+CREATE INDEX IF NOT EXISTS idx_refund_details_transaction_detail_id ON refund_details (transaction_detail_id);
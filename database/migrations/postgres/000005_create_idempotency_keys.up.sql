@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key            VARCHAR(255) PRIMARY KEY,
+    request_hash   VARCHAR(64) NOT NULL,
+    transaction_id UUID REFERENCES transactions (id),
+    response       BYTEA,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_cashier_id;
+
+ALTER TABLE transactions DROP COLUMN terminal_id;
+ALTER TABLE transactions DROP COLUMN cashier_id;
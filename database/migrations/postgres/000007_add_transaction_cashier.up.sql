@@ -0,0 +1,4 @@
+ALTER TABLE transactions ADD COLUMN cashier_id UUID REFERENCES users (id);
+ALTER TABLE transactions ADD COLUMN terminal_id VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_cashier_id ON transactions (cashier_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_transactions_shift_id;
+
+ALTER TABLE transactions DROP COLUMN shift_id;
+
+DROP TABLE IF EXISTS cash_movements;
+DROP TABLE IF EXISTS shifts;
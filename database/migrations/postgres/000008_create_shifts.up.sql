@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS shifts (
+    id            UUID PRIMARY KEY,
+    cashier_id    UUID NOT NULL REFERENCES users (id),
+    terminal_id   VARCHAR(50),
+    status        VARCHAR(16) NOT NULL,
+    opening_float BIGINT NOT NULL CHECK (opening_float >= 0),
+    expected_cash BIGINT,
+    counted_cash  BIGINT,
+    variance      BIGINT,
+    opening_note  VARCHAR(255),
+    closing_note  VARCHAR(255),
+    opened_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at     TIMESTAMPTZ
+);
+
+-- satu kasir hanya boleh punya satu shift terbuka
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shifts_open_cashier ON shifts (cashier_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_shifts_opened_at ON shifts (opened_at);
+
+CREATE TABLE IF NOT EXISTS cash_movements (
+    id         UUID PRIMARY KEY,
+    shift_id   UUID NOT NULL REFERENCES shifts (id) ON DELETE CASCADE,
+    type       VARCHAR(16) NOT NULL,
+    amount     BIGINT NOT NULL CHECK (amount > 0),
+    reason     VARCHAR(255) NOT NULL,
+    created_by UUID REFERENCES users (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cash_movements_shift_id ON cash_movements (shift_id);
+
+ALTER TABLE transactions ADD COLUMN shift_id UUID REFERENCES shifts (id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_shift_id ON transactions (shift_id);
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS stock_opnames (
+    id          UUID PRIMARY KEY,
+    category_id UUID REFERENCES categories (id),
+    status      VARCHAR(16) NOT NULL,
+    note        VARCHAR(255),
+    created_by  UUID REFERENCES users (id),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_by   UUID REFERENCES users (id),
+    closed_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_opnames_status ON stock_opnames (status);
+
+-- system_stock adalah stok sistem saat produk dihitung, selisih dihitung terhadap angka ini
+-- supaya penjualan selama sesi tidak ikut dianggap selisih
+CREATE TABLE IF NOT EXISTS stock_opname_items (
+    opname_id        UUID NOT NULL REFERENCES stock_opnames (id) ON DELETE CASCADE,
+    product_id       UUID NOT NULL REFERENCES product (id) ON DELETE CASCADE,
+    start_stock      INTEGER NOT NULL,
+    system_stock     INTEGER,
+    counted_quantity INTEGER CHECK (counted_quantity >= 0),
+    counted_by       UUID REFERENCES users (id),
+    counted_at       TIMESTAMPTZ,
+    adjustment       INTEGER,
+    PRIMARY KEY (opname_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_opname_items_product_id ON stock_opname_items (product_id);
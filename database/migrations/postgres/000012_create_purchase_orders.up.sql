@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS suppliers (
+    id           UUID PRIMARY KEY,
+    name         VARCHAR(100) NOT NULL,
+    contact_name VARCHAR(100),
+    phone        VARCHAR(30),
+    email        VARCHAR(100),
+    address      VARCHAR(255),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_suppliers_name ON suppliers (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id          UUID PRIMARY KEY,
+    supplier_id UUID NOT NULL REFERENCES suppliers (id),
+    status      VARCHAR(20) NOT NULL,
+    note        VARCHAR(255),
+    total_cost  BIGINT NOT NULL DEFAULT 0,
+    created_by  UUID REFERENCES users (id),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at     TIMESTAMPTZ,
+    closed_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_status ON purchase_orders (status);
+
+CREATE TABLE IF NOT EXISTS purchase_order_items (
+    id                UUID PRIMARY KEY,
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    product_id        UUID NOT NULL REFERENCES product (id),
+    quantity          INTEGER NOT NULL CHECK (quantity > 0),
+    received_quantity INTEGER NOT NULL DEFAULT 0 CHECK (received_quantity >= 0 AND received_quantity <= quantity),
+    unit_cost         BIGINT NOT NULL CHECK (unit_cost >= 0),
+    UNIQUE (purchase_order_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_order_items_product_id ON purchase_order_items (product_id);
+
+-- satu goods receipt adalah satu kali barang datang, PO bisa diterima bertahap
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id                UUID PRIMARY KEY,
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    note              VARCHAR(255),
+    received_by       UUID REFERENCES users (id),
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_goods_receipts_purchase_order_id ON goods_receipts (purchase_order_id);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_items (
+    receipt_id             UUID NOT NULL REFERENCES goods_receipts (id) ON DELETE CASCADE,
+    purchase_order_item_id UUID NOT NULL REFERENCES purchase_order_items (id) ON DELETE CASCADE,
+    product_id             UUID NOT NULL REFERENCES product (id),
+    quantity               INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost              BIGINT NOT NULL CHECK (unit_cost >= 0),
+    PRIMARY KEY (receipt_id, purchase_order_item_id)
+);
@@ -0,0 +1,3 @@
+-- deleted_at terisi berarti data diarsipkan, baris tetap disimpan untuk riwayat transaksi
+ALTER TABLE categories ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE product ADD COLUMN deleted_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_barcodes;
+DROP INDEX IF EXISTS idx_product_sku;
@@ -0,0 +1,11 @@
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_sku ON product (sku);
+
+-- satu produk bisa punya beberapa barcode EAN/UPC, code sebagai primary key
+-- supaya lookup saat scan cukup satu index
+CREATE TABLE IF NOT EXISTS product_barcodes (
+    code       VARCHAR(14) PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES product (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_barcodes_product_id ON product_barcodes (product_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS product_variant_options;
+DROP INDEX IF EXISTS idx_product_parent_id;
+ALTER TABLE product DROP COLUMN variant_name;
+ALTER TABLE product DROP COLUMN has_variants;
+ALTER TABLE product DROP COLUMN parent_id;
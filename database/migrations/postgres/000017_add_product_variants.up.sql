@@ -0,0 +1,16 @@
+-- varian adalah baris product biasa dengan parent_id, jadi stok, harga, SKU, barcode,
+-- movement dan riwayat transaksinya memakai jalur yang sama dengan produk tanpa varian.
+-- Produk induk (has_variants) hanya pengelompokan dan tidak bisa dijual langsung
+ALTER TABLE product ADD COLUMN parent_id UUID REFERENCES product (id);
+ALTER TABLE product ADD COLUMN has_variants BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE product ADD COLUMN variant_name VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_product_parent_id ON product (parent_id);
+
+CREATE TABLE IF NOT EXISTS product_variant_options (
+    product_id UUID NOT NULL REFERENCES product (id),
+    position   INTEGER NOT NULL,
+    name       VARCHAR(50) NOT NULL,
+    value      VARCHAR(100) NOT NULL,
+    PRIMARY KEY (product_id, position)
+);
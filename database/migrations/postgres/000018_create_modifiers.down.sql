@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS transaction_detail_modifiers;
+DROP TABLE IF EXISTS modifier_group_categories;
+DROP TABLE IF EXISTS modifier_group_products;
+DROP TABLE IF EXISTS modifiers;
+DROP TABLE IF EXISTS modifier_groups;
@@ -0,0 +1,49 @@
+-- modifier_groups dipasang ke produk atau kategori. min_select 0 berarti opsional,
+-- max_select membatasi jumlah modifier yang boleh dipilih per item
+CREATE TABLE IF NOT EXISTS modifier_groups (
+    id         UUID PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    min_select INTEGER NOT NULL DEFAULT 0,
+    max_select INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS modifiers (
+    id          UUID PRIMARY KEY,
+    group_id    UUID NOT NULL REFERENCES modifier_groups (id) ON DELETE CASCADE,
+    name        VARCHAR(100) NOT NULL,
+    price_delta BIGINT NOT NULL DEFAULT 0,
+    position    INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_modifiers_group_id ON modifiers (group_id);
+
+CREATE TABLE IF NOT EXISTS modifier_group_products (
+    group_id   UUID NOT NULL REFERENCES modifier_groups (id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES product (id),
+    PRIMARY KEY (group_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_modifier_group_products_product_id ON modifier_group_products (product_id);
+
+CREATE TABLE IF NOT EXISTS modifier_group_categories (
+    group_id    UUID NOT NULL REFERENCES modifier_groups (id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories (id),
+    PRIMARY KEY (group_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_modifier_group_categories_category_id ON modifier_group_categories (category_id);
+
+-- snapshot modifier yang dipilih per item transaksi, tanpa foreign key ke modifiers
+-- supaya riwayat tetap utuh walaupun modifier diubah atau dihapus.
+-- price_delta adalah tambahan harga per unit item
+CREATE TABLE IF NOT EXISTS transaction_detail_modifiers (
+    transaction_detail_id UUID NOT NULL REFERENCES transaction_details (id),
+    position              INTEGER NOT NULL,
+    modifier_id           UUID NOT NULL,
+    group_name            VARCHAR(100) NOT NULL,
+    name                  VARCHAR(100) NOT NULL,
+    price_delta           BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (transaction_detail_id, position)
+);
@@ -0,0 +1 @@
+ALTER TABLE refund_details DROP COLUMN subtotal;
@@ -0,0 +1,6 @@
+-- amount sekarang termasuk bagian service charge dan PPN (uang yang dikembalikan),
+-- subtotal menyimpan bagian sebelum pajak untuk laporan revenue. Refund lama dihitung
+-- dari subtotal sehingga nilainya sama
+ALTER TABLE refund_details ADD COLUMN subtotal BIGINT NOT NULL DEFAULT 0;
+
+UPDATE refund_details SET subtotal = amount;
@@ -0,0 +1,9 @@
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+
+-- key yang sama dari beberapa kasir disisakan yang paling awal
+DELETE FROM idempotency_keys a
+USING idempotency_keys b
+WHERE a.key = b.key AND (a.created_at, a.ctid) > (b.created_at, b.ctid);
+
+ALTER TABLE idempotency_keys DROP COLUMN cashier_id;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (key);
@@ -0,0 +1,13 @@
+-- idempotency key sekarang unik per kasir, key yang sama dari kasir lain tidak saling bentrok.
+-- Key lama diisi kasir dari transaksinya, key tanpa kasir tidak bisa di-replay lagi sehingga dihapus
+ALTER TABLE idempotency_keys ADD COLUMN cashier_id UUID REFERENCES users (id);
+
+UPDATE idempotency_keys k SET cashier_id = t.cashier_id
+FROM transactions t
+WHERE t.id = k.transaction_id;
+
+DELETE FROM idempotency_keys WHERE cashier_id IS NULL;
+
+ALTER TABLE idempotency_keys ALTER COLUMN cashier_id SET NOT NULL;
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (cashier_id, key);
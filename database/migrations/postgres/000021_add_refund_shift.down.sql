@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_refunds_shift_id;
+
+ALTER TABLE refunds DROP COLUMN cashier_id;
+ALTER TABLE refunds DROP COLUMN shift_id;
@@ -0,0 +1,11 @@
+-- refund dan void dicatat di shift kasir yang mengembalikan uang, bukan shift transaksi asalnya,
+-- supaya laporan Z shift yang sudah ditutup tidak berubah. Refund lama tetap dihitung di shift
+-- transaksi asalnya seperti sebelumnya, kasirnya diambil dari mutasi stok refund tersebut
+ALTER TABLE refunds ADD COLUMN shift_id UUID REFERENCES shifts (id);
+ALTER TABLE refunds ADD COLUMN cashier_id UUID REFERENCES users (id);
+
+UPDATE refunds SET
+    shift_id = (SELECT t.shift_id FROM transactions t WHERE t.id = refunds.transaction_id),
+    cashier_id = (SELECT sm.created_by FROM stock_movements sm WHERE sm.reference_id = refunds.id LIMIT 1);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_shift_id ON refunds (shift_id);
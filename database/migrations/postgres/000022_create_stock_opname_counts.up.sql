@@ -0,0 +1,17 @@
+-- hasil hitung per penghitung, produk yang dihitung beberapa orang (misalnya di rak dan gudang)
+-- dijumlahkan ke stock_opname_items.counted_quantity. Hitung ulang oleh orang yang sama
+-- mengganti hitungannya sendiri
+CREATE TABLE IF NOT EXISTS stock_opname_counts (
+    opname_id        UUID NOT NULL,
+    product_id       UUID NOT NULL,
+    counted_by       UUID NOT NULL REFERENCES users (id),
+    counted_quantity INTEGER NOT NULL CHECK (counted_quantity >= 0),
+    counted_at       TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (opname_id, product_id, counted_by),
+    FOREIGN KEY (opname_id, product_id) REFERENCES stock_opname_items (opname_id, product_id) ON DELETE CASCADE
+);
+
+INSERT INTO stock_opname_counts (opname_id, product_id, counted_by, counted_quantity, counted_at)
+SELECT opname_id, product_id, counted_by, counted_quantity, counted_at
+FROM stock_opname_items
+WHERE counted_quantity IS NOT NULL AND counted_by IS NOT NULL AND counted_at IS NOT NULL;
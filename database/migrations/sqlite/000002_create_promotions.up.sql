@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS promotions (
+    id              TEXT PRIMARY KEY,
+    name            VARCHAR(255) NOT NULL,
+    type            VARCHAR(32) NOT NULL,
+    code            VARCHAR(50) UNIQUE,
+    product_id      TEXT REFERENCES product (id),
+    value           BIGINT NOT NULL DEFAULT 0,
+    buy_quantity    INTEGER NOT NULL DEFAULT 0,
+    get_quantity    INTEGER NOT NULL DEFAULT 0,
+    bundle_quantity INTEGER NOT NULL DEFAULT 0,
+    bundle_price    BIGINT NOT NULL DEFAULT 0,
+    min_purchase    BIGINT NOT NULL DEFAULT 0,
+    max_discount    BIGINT NOT NULL DEFAULT 0,
+    start_at        TIMESTAMP NOT NULL,
+    end_at          TIMESTAMP NOT NULL,
+    is_active       BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at      TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotions_product_id ON promotions (product_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS transaction_payments;
+DROP TABLE IF EXISTS transaction_details;
+DROP TABLE IF EXISTS transactions;
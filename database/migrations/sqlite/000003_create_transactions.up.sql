@@ -0,0 +1,51 @@
+-- transactions dan transaction_details sudah ada sebelum migrasi berversi. CREATE hanya
+-- berisi skema awal, kolom baru ditambah dengan ALTER supaya database lama ikut ter-upgrade
+CREATE TABLE IF NOT EXISTS transactions (
+    id           TEXT PRIMARY KEY,
+    total_amount BIGINT NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+ALTER TABLE transactions ADD COLUMN subtotal BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN service_charge BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN tax_base BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN tax_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE transactions ADD COLUMN discount_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN promotion_id TEXT REFERENCES promotions (id);
+ALTER TABLE transactions ADD COLUMN promo_code VARCHAR(50);
+ALTER TABLE transactions ADD COLUMN paid_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN change_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'completed';
+
+-- transaksi lama belum mengenal pajak dan diskon, subtotalnya sama dengan total
+UPDATE transactions SET subtotal = total_amount, tax_base = total_amount, paid_amount = total_amount;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions (created_at);
+
+CREATE TABLE IF NOT EXISTS transaction_details (
+    id             TEXT PRIMARY KEY,
+    transaction_id TEXT NOT NULL REFERENCES transactions (id),
+    product_id     TEXT NOT NULL REFERENCES product (id),
+    quantity       INTEGER NOT NULL CHECK (quantity > 0),
+    subtotal       BIGINT NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+ALTER TABLE transaction_details ADD COLUMN discount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE transaction_details ADD COLUMN promotion_id TEXT REFERENCES promotions (id);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_details_transaction_id ON transaction_details (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_details_product_id ON transaction_details (product_id);
+
+CREATE TABLE IF NOT EXISTS transaction_payments (
+    id             TEXT PRIMARY KEY,
+    transaction_id TEXT NOT NULL REFERENCES transactions (id),
+    method         VARCHAR(32) NOT NULL,
+    amount         BIGINT NOT NULL CHECK (amount > 0),
+    change_amount  BIGINT NOT NULL DEFAULT 0,
+    reference      VARCHAR(100) NOT NULL DEFAULT '',
+    created_at     TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_payments_transaction_id ON transaction_payments (transaction_id);
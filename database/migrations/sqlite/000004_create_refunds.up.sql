@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS refunds (
+    id             TEXT PRIMARY KEY,
+    transaction_id TEXT NOT NULL REFERENCES transactions (id),
+    type           VARCHAR(16) NOT NULL,
+    reason         VARCHAR(255) NOT NULL,
+    total_amount   BIGINT NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_transaction_id ON refunds (transaction_id);
+
+CREATE TABLE IF NOT EXISTS refund_details (
+    id                    TEXT PRIMARY KEY,
+    refund_id             TEXT NOT NULL REFERENCES refunds (id),
+    transaction_detail_id TEXT NOT NULL REFERENCES transaction_details (id),
+    product_id            TEXT NOT NULL REFERENCES product (id),
+    quantity              INTEGER NOT NULL CHECK (quantity > 0),
+    amount                BIGINT NOT NULL,
+    created_at            TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_refund_details_refund_id ON refund_details (refund_id);
+CREATE INDEX IF NOT EXISTS idx_refund_details_transaction_detail_id ON refund_details (transaction_detail_id);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id           TEXT PRIMARY KEY,
+    product_id   TEXT NOT NULL REFERENCES product (id) ON DELETE CASCADE,
+    type         VARCHAR(20) NOT NULL,
+    quantity     INTEGER NOT NULL CHECK (quantity <> 0),
+    stock_after  INTEGER NOT NULL CHECK (stock_after >= 0),
+    reason       VARCHAR(255) NOT NULL DEFAULT '',
+    reference_id TEXT,
+    created_by   TEXT REFERENCES users (id),
+    created_at   TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product_id ON stock_movements (product_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_reference_id ON stock_movements (reference_id);
+
+-- saldo awal supaya jumlah movement sama dengan stok produk yang sudah ada,
+-- id produk dipakai sebagai id movement karena hanya ada satu saldo awal per produk
+INSERT INTO stock_movements (id, product_id, type, quantity, stock_after, reason, created_at)
+SELECT id, id, 'adjustment', stock, stock, 'saldo awal', strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+FROM product
+WHERE stock <> 0;
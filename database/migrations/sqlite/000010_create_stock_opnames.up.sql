@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS stock_opnames (
+    id          TEXT PRIMARY KEY,
+    category_id TEXT REFERENCES categories (id),
+    status      VARCHAR(16) NOT NULL,
+    note        VARCHAR(255),
+    created_by  TEXT REFERENCES users (id),
+    created_at  TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    closed_by   TEXT REFERENCES users (id),
+    closed_at   TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_opnames_status ON stock_opnames (status);
+
+-- system_stock adalah stok sistem saat produk dihitung, selisih dihitung terhadap angka ini
+-- supaya penjualan selama sesi tidak ikut dianggap selisih
+CREATE TABLE IF NOT EXISTS stock_opname_items (
+    opname_id        TEXT NOT NULL REFERENCES stock_opnames (id) ON DELETE CASCADE,
+    product_id       TEXT NOT NULL REFERENCES product (id) ON DELETE CASCADE,
+    start_stock      INTEGER NOT NULL,
+    system_stock     INTEGER,
+    counted_quantity INTEGER CHECK (counted_quantity >= 0),
+    counted_by       TEXT REFERENCES users (id),
+    counted_at       TIMESTAMP,
+    adjustment       INTEGER,
+    PRIMARY KEY (opname_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_opname_items_product_id ON stock_opname_items (product_id);
@@ -0,0 +1,2 @@
+ALTER TABLE product DROP COLUMN reorder_quantity;
+ALTER TABLE product DROP COLUMN reorder_point;
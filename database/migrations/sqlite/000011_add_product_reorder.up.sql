@@ -0,0 +1,2 @@
+ALTER TABLE product ADD COLUMN reorder_point INT CHECK (reorder_point >= 0);
+ALTER TABLE product ADD COLUMN reorder_quantity INT NOT NULL DEFAULT 0 CHECK (reorder_quantity >= 0);
@@ -0,0 +1,2 @@
+ALTER TABLE transaction_details DROP COLUMN unit_cost;
+ALTER TABLE product DROP COLUMN cost;
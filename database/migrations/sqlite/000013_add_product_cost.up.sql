@@ -0,0 +1,5 @@
+-- cost adalah HPP rata-rata bergerak, diperbarui setiap penerimaan barang dari PO
+ALTER TABLE product ADD COLUMN cost BIGINT NOT NULL DEFAULT 0 CHECK (cost >= 0);
+
+-- unit_cost adalah HPP produk saat transaksi terjadi, transaksi lama bernilai 0
+ALTER TABLE transaction_details ADD COLUMN unit_cost BIGINT NOT NULL DEFAULT 0;
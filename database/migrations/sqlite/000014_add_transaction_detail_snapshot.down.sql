@@ -0,0 +1,6 @@
+ALTER TABLE transaction_details DROP COLUMN category_name;
+ALTER TABLE transaction_details DROP COLUMN category_id;
+ALTER TABLE transaction_details DROP COLUMN unit_price;
+ALTER TABLE transaction_details DROP COLUMN sku;
+ALTER TABLE transaction_details DROP COLUMN product_name;
+ALTER TABLE product DROP COLUMN sku;
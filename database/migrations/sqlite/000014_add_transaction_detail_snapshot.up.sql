@@ -0,0 +1,21 @@
+-- sku produk, diisi lewat master produk
+ALTER TABLE product ADD COLUMN sku VARCHAR(64);
+
+-- snapshot produk saat transaksi terjadi supaya riwayat tidak berubah
+-- ketika produk diubah atau dihapus
+ALTER TABLE transaction_details ADD COLUMN product_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE transaction_details ADD COLUMN sku VARCHAR(64);
+ALTER TABLE transaction_details ADD COLUMN unit_price BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE transaction_details ADD COLUMN category_id TEXT;
+ALTER TABLE transaction_details ADD COLUMN category_name VARCHAR(255) NOT NULL DEFAULT '';
+
+-- transaksi lama diisi dari data produk saat ini, harga satuan dihitung dari subtotal
+UPDATE transaction_details SET
+    product_name = COALESCE((SELECT p.name FROM product p WHERE p.id = transaction_details.product_id), ''),
+    category_id = (SELECT p.category_id FROM product p WHERE p.id = transaction_details.product_id),
+    category_name = COALESCE((
+        SELECT c.name FROM product p
+        JOIN categories c ON c.id = p.category_id
+        WHERE p.id = transaction_details.product_id
+    ), ''),
+    unit_price = (subtotal + discount) / quantity;
@@ -0,0 +1,11 @@
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_sku ON product (sku);
+
+-- satu produk bisa punya beberapa barcode EAN/UPC, code sebagai primary key
+-- supaya lookup saat scan cukup satu index
+CREATE TABLE IF NOT EXISTS product_barcodes (
+    code       VARCHAR(14) PRIMARY KEY,
+    product_id TEXT NOT NULL REFERENCES product (id),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_barcodes_product_id ON product_barcodes (product_id);
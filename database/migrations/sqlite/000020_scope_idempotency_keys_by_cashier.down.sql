@@ -0,0 +1,16 @@
+CREATE TABLE idempotency_keys_old (
+    key            VARCHAR(255) PRIMARY KEY,
+    request_hash   VARCHAR(64) NOT NULL,
+    transaction_id TEXT REFERENCES transactions (id),
+    response       BLOB,
+    created_at     TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+-- key yang sama dari beberapa kasir disisakan yang paling awal
+INSERT OR IGNORE INTO idempotency_keys_old (key, request_hash, transaction_id, response, created_at)
+SELECT key, request_hash, transaction_id, response, created_at
+FROM idempotency_keys
+ORDER BY created_at;
+
+DROP TABLE idempotency_keys;
+ALTER TABLE idempotency_keys_old RENAME TO idempotency_keys;
@@ -0,0 +1,21 @@
+-- idempotency key sekarang unik per kasir, key yang sama dari kasir lain tidak saling bentrok.
+-- SQLite tidak bisa mengganti primary key sehingga tabel dibuat ulang. Key lama diisi kasir
+-- dari transaksinya, key tanpa kasir tidak bisa di-replay lagi sehingga tidak disalin
+CREATE TABLE idempotency_keys_new (
+    cashier_id     TEXT NOT NULL REFERENCES users (id),
+    key            VARCHAR(255) NOT NULL,
+    request_hash   VARCHAR(64) NOT NULL,
+    transaction_id TEXT REFERENCES transactions (id),
+    response       BLOB,
+    created_at     TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    PRIMARY KEY (cashier_id, key)
+);
+
+INSERT INTO idempotency_keys_new (cashier_id, key, request_hash, transaction_id, response, created_at)
+SELECT t.cashier_id, k.key, k.request_hash, k.transaction_id, k.response, k.created_at
+FROM idempotency_keys k
+JOIN transactions t ON t.id = k.transaction_id
+WHERE t.cashier_id IS NOT NULL;
+
+DROP TABLE idempotency_keys;
+ALTER TABLE idempotency_keys_new RENAME TO idempotency_keys;
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/auth"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+type AuthHandler struct {
+	service     service.AuthService
+	userService service.UserService
+	validator   validator.ValidatePkg
+}
+
+func NewAuthHandler(srv service.AuthService, userSrv service.UserService, validator validator.ValidatePkg) *AuthHandler {
+	return &AuthHandler{
+		service:     srv,
+		userService: userSrv,
+		validator:   validator,
+	}
+}
+
+// @Summary      Login
+// @Description  login with username and password, returns access and refresh token
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param		 login	body		dto.LoginRequest	true	"Credentials"
+// @Success      200  {object} 			map[string]any
+// @Router       /api/auth/login [post]
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.LoginRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	token, err := h.service.Login(&body)
+
+	if err != nil {
+		response.Failed(
+			"Failed login",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully login",
+		token,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Refresh token
+// @Description  exchange a refresh token for a new access and refresh token
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param		 refresh	body		dto.RefreshRequest	true	"Refresh token"
+// @Success      200  {object} 			map[string]any
+// @Router       /api/auth/refresh [post]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.RefreshRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	token, err := h.service.Refresh(&body)
+
+	if err != nil {
+		response.Failed(
+			"Failed refresh token",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully refresh token",
+		token,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Logout
+// @Description  revoke a refresh token
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param		 refresh	body		dto.RefreshRequest	true	"Refresh token"
+// @Success      200  {object} 			map[string]any
+// @Router       /api/auth/logout [post]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.RefreshRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.service.Logout(&body); err != nil {
+		response.Failed(
+			"Failed logout",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully logout",
+		nil,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Current user
+// @Description  get the logged in user
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]any
+// @Router       /api/auth/me [get]
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		response.Failed(
+			"Unauthorized",
+			utils.ErrUnauthenticated,
+		).JSON(w, http.StatusUnauthorized)
+		return
+	}
+
+	user, err := h.userService.GetUserByID(claims.Subject)
+
+	if err != nil {
+		response.Failed(
+			"Failed get user",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get user",
+		user,
+		nil,
+	).JSON(w, http.StatusOK)
+}
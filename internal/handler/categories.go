@@ -1,23 +1,25 @@
 package handler
 
 import (
-	"database/sql"
-	"errors"
 	"net/http"
 
 	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
 	"github.com/Muh-Sidik/kasir-api/internal/service"
 )
 
 type CategoryHandler struct {
-	service service.CategoryService
+	service   service.CategoryService
+	validator validator.ValidatePkg
 }
 
-func NewCategoryHandler(srv service.CategoryService) *CategoryHandler {
+func NewCategoryHandler(srv service.CategoryService, validator validator.ValidatePkg) *CategoryHandler {
 	return &CategoryHandler{
-		service: srv,
+		service:   srv,
+		validator: validator,
 	}
 }
 
@@ -26,7 +28,9 @@ func NewCategoryHandler(srv service.CategoryService) *CategoryHandler {
 // @Tags         Categories
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param		 name 		query		string 	false 	"Search by Category"
+// @Param		 include_archived 	query		bool 	false 	"Include archived categories"
 // @Param		 page		query		int	false	"Page number"
 // @Param		 per_page	query		int	false	"Items per page"
 // @Success      200  {object}  map[string]any
@@ -38,13 +42,23 @@ func (h *CategoryHandler) Categories(w http.ResponseWriter, r *http.Request) {
 	perPage := queryParam.Get("per_page")
 
 	paginate := request.Paginate(page, perPage)
-	category, total, err := h.service.GetCategories(paginate, search)
+
+	archived, err := includeArchived(r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	category, total, err := h.service.GetCategories(paginate, search, archived)
 
 	if err != nil {
 		response.Failed(
 			"Failed get categories",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -64,6 +78,7 @@ func (h *CategoryHandler) Categories(w http.ResponseWriter, r *http.Request) {
 // @Tags         Categories
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param		 category	body		dto.CategoryRequest	true	"Add category"
 // @Success      200  {object} 			map[string]any
 // @Router       /api/categories [post]
@@ -73,7 +88,15 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 		response.Failed(
 			"Invalid Request",
 			err,
-		).JSON(w, http.StatusBadRequest)
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -83,7 +106,7 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 		response.Failed(
 			"Failed create category",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -99,27 +122,27 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 // @Tags			Categories
 // @Accept			json
 // @Produce			json
+// @Security			BearerAuth
 // @Param			id	path		int		true	"Category ID"
 // @Success			200	{object}	map[string]any
 // @Router			/api/categories/{id} [get]
 func (h *CategoryHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request) {
-	id := r.PathValue("id")
+	id, err := pathID(r, "id", utils.ErrCategoryNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get category",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
 
 	category, err := h.service.GetCategoryByID(id)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			response.Failed(
-				"Not Found category",
-				err,
-			).JSON(w, http.StatusNotFound)
-			return
-		}
-
 		response.Failed(
 			"Failed get category",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -135,37 +158,45 @@ func (h *CategoryHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request
 // @Tags			Categories
 // @Accept			json
 // @Produce		json
+// @Security		BearerAuth
 // @Param			id		path		string					true	"Category ID"
 // @Param			account	body		model.Categories	true	"Update account"
 // @Success		200		{object}	map[string]any
 // @Router			/api/categories/{id} [put]
 func (h *CategoryHandler) UpdateCategoryByID(w http.ResponseWriter, r *http.Request) {
-	id := r.PathValue("id")
+	id, err := pathID(r, "id", utils.ErrCategoryNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed update category",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
 
 	body, err := request.BindJSON[dto.CategoryRequest](r)
 	if err != nil {
 		response.Failed(
 			"Invalid Request",
 			err,
-		).JSON(w, http.StatusBadRequest)
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
 		return
 	}
 
 	category, err := h.service.UpdateCategoryByID(id, &body)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			response.Failed(
-				"Not Found category",
-				err,
-			).JSON(w, http.StatusNotFound)
-			return
-		}
-
 		response.Failed(
 			"Failed update category",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -177,31 +208,31 @@ func (h *CategoryHandler) UpdateCategoryByID(w http.ResponseWriter, r *http.Requ
 }
 
 // @Summary			Delete a category
-// @Description		delete category by ID
+// @Description		archive category by ID, active products must be archived first
 // @Tags			Categories
 // @Accept			json
 // @Produce			json
+// @Security			BearerAuth
 // @Param			id	path		int		true	"Category ID"
 // @Success			200	{object}	map[string]any
 // @Router			/api/categories/{id} [delete]
 func (h *CategoryHandler) DeleteCategoryByID(w http.ResponseWriter, r *http.Request) {
-	id := r.PathValue("id")
+	id, err := pathID(r, "id", utils.ErrCategoryNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed delete category",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
 
-	err := h.service.DeleteCategoryByID(id)
+	err = h.service.DeleteCategoryByID(id)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			response.Failed(
-				"Not Found category",
-				err,
-			).JSON(w, http.StatusNotFound)
-			return
-		}
-
 		response.Failed(
 			"Failed delete category",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -211,3 +242,39 @@ func (h *CategoryHandler) DeleteCategoryByID(w http.ResponseWriter, r *http.Requ
 		nil,
 	).JSON(w, http.StatusOK)
 }
+
+// @Summary			Restore a category
+// @Description		restore archived category by ID
+// @Tags			Categories
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Category ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/categories/{id}/restore [post]
+func (h *CategoryHandler) RestoreCategoryByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrCategoryNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed restore category",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	category, err := h.service.RestoreCategoryByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed restore category",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully restore category",
+		category,
+		nil,
+	).JSON(w, http.StatusOK)
+}
@@ -1,8 +1,17 @@
 package handler
 
 import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/auth"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
 	"github.com/Muh-Sidik/kasir-api/internal/service"
+	"github.com/gofrs/uuid/v5"
 )
 
 type Handler struct {
@@ -10,3 +19,58 @@ type Handler struct {
 	CategorySrv service.CategoryService
 	Validator   validator.ValidatePkg
 }
+
+// actorFromRequest mengambil user yang login, menulis response 401 jika tidak ada
+func actorFromRequest(w http.ResponseWriter, r *http.Request) (*auth.Claims, bool) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		response.Failed(
+			"Unauthorized",
+			utils.ErrUnauthenticated,
+		).JSON(w, http.StatusUnauthorized)
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// actorID mengembalikan id user yang login untuk audit, nil jika tidak ada
+func actorID(r *http.Request) *uuid.UUID {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		return nil
+	}
+
+	id, err := uuid.FromString(claims.Subject)
+	if err != nil {
+		return nil
+	}
+
+	return &id
+}
+
+// includeArchived membaca query include_archived, kosong berarti false
+func includeArchived(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("include_archived")
+	if value == "" {
+		return false, nil
+	}
+
+	include, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid include_archived: %w", err))
+	}
+
+	return include, nil
+}
+
+// pathID membaca id uuid dari path. Id yang bukan uuid pasti tidak ada,
+// sehingga langsung dikembalikan sebagai notFound tanpa query ke database
+func pathID(r *http.Request, name string, notFound error) (string, error) {
+	id, err := uuid.FromString(r.PathValue(name))
+	if err != nil {
+		return "", notFound
+	}
+
+	return id.String(), nil
+}
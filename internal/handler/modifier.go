@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+type ModifierHandler struct {
+	service   service.ModifierService
+	validator validator.ValidatePkg
+}
+
+func NewModifierHandler(srv service.ModifierService, validator validator.ValidatePkg) *ModifierHandler {
+	return &ModifierHandler{
+		service:   srv,
+		validator: validator,
+	}
+}
+
+// @Summary      Show modifier groups
+// @Description  get list modifier group
+// @Tags         Modifier
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 name 		query		string 	false 	"Search by Modifier Group Name"
+// @Param		 product_id	query		string 	false 	"Groups available for the product"
+// @Param		 page		query		int		false	"Page number"
+// @Param		 per_page	query		int		false	"Items per page"
+// @Success      200  {object}  map[string]any
+// @Router       /api/modifier-groups [get]
+func (h *ModifierHandler) ModifierGroups(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	page := queryParam.Get("page")
+	perPage := queryParam.Get("per_page")
+	paginate := request.Paginate(page, perPage)
+
+	queryDto := &dto.ModifierGroupQuery{
+		Name:      queryParam.Get("name"),
+		ProductID: queryParam.Get("product_id"),
+	}
+	queryDto.Limit = paginate.Limit
+	queryDto.Offset = paginate.Offset
+
+	if err := queryDto.Validate(); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	groups, total, err := h.service.GetModifierGroups(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get modifier groups",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get modifier groups",
+		groups,
+		&response.Meta{
+			Total: total,
+			Page:  paginate.Page,
+			Limit: paginate.Limit,
+		},
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Create modifier group
+// @Description  create a modifier group
+// @Tags         Modifier
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 group		body		dto.ModifierGroupRequest	true	"Add modifier group"
+// @Success      201  {object} 			map[string]any
+// @Router       /api/modifier-groups [post]
+func (h *ModifierHandler) CreateModifierGroup(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.ModifierGroupRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	group, err := h.service.CreateModifierGroup(&body)
+
+	if err != nil {
+		response.Failed(
+			"Failed create modifier group",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully create modifier group",
+		group,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary			Show a modifier group
+// @Description		get modifier group by ID
+// @Tags			Modifier
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Modifier Group ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/modifier-groups/{id} [get]
+func (h *ModifierHandler) GetModifierGroupByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrModifierGroupNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get modifier group",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	group, err := h.service.GetModifierGroupByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed get modifier group",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get modifier group",
+		group,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary		Update a modifier group
+// @Description	Update modifier group by ID
+// @Tags			Modifier
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id			path		string				true	"Modifier Group ID"
+// @Param			group		body		dto.ModifierGroupRequest	true	"Update modifier group"
+// @Success		200		{object}	map[string]any
+// @Router			/api/modifier-groups/{id} [put]
+func (h *ModifierHandler) UpdateModifierGroupByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrModifierGroupNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed update modifier group",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body, err := request.BindJSON[dto.ModifierGroupRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	group, err := h.service.UpdateModifierGroupByID(id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed update modifier group",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully update modifier group",
+		group,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary			Delete a modifier group
+// @Description		delete modifier group by ID, sold modifiers stay in transaction history
+// @Tags			Modifier
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Modifier Group ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/modifier-groups/{id} [delete]
+func (h *ModifierHandler) DeleteModifierGroupByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrModifierGroupNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed delete modifier group",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	err = h.service.DeleteModifierGroupByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed delete modifier group",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully delete modifier group",
+		nil,
+		nil,
+	).JSON(w, http.StatusOK)
+}
@@ -1,24 +1,27 @@
 package handler
 
 import (
-	"database/sql"
-	"errors"
+	"log"
 	"net/http"
 
 	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/label"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
 	"github.com/Muh-Sidik/kasir-api/internal/service"
 )
 
 type ProductHandler struct {
-	service service.ProductService
+	service   service.ProductService
+	validator validator.ValidatePkg
 }
 
-func NewProductHandler(srv service.ProductService) *ProductHandler {
+func NewProductHandler(srv service.ProductService, validator validator.ValidatePkg) *ProductHandler {
 	return &ProductHandler{
-		service: srv,
+		service:   srv,
+		validator: validator,
 	}
 }
 
@@ -27,8 +30,10 @@ func NewProductHandler(srv service.ProductService) *ProductHandler {
 // @Tags         Product
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param		 name 			query		string 	false 	"Search by Product Name"
 // @Param		 categoryId 	query		string 	false 	"Filter by category id"
+// @Param		 include_archived 	query		bool 	false 	"Include archived products"
 // @Param		 page			query		int		false	"Page number"
 // @Param		 per_page		query		int		false	"Items per page"
 // @Success      200  {object}  map[string]any
@@ -46,13 +51,23 @@ func (h *ProductHandler) Products(w http.ResponseWriter, r *http.Request) {
 	queryDto.Limit = paginate.Limit
 	queryDto.Offset = paginate.Offset
 
+	archived, err := includeArchived(r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+	queryDto.IncludeArchived = archived
+
 	product, total, err := h.service.GetProduct(queryDto)
 
 	if err != nil {
 		response.Failed(
 			"Failed get products",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -72,6 +87,7 @@ func (h *ProductHandler) Products(w http.ResponseWriter, r *http.Request) {
 // @Tags         Product
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param		 product	body		dto.ProductRequest	true	"Add product"
 // @Success      200  {object} 			map[string]any
 // @Router       /api/product [post]
@@ -81,24 +97,26 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		response.Failed(
 			"Invalid Request",
 			err,
-		).JSON(w, http.StatusBadRequest)
+		).JSON(w, response.Status(err))
 		return
 	}
 
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.UserID = actorID(r)
 	product, err := h.service.CreateProduct(&body)
 
 	if err != nil {
-		if errors.Is(err, utils.ErrCategoryNotFound) {
-			response.Failed(
-				"Not Found category",
-				err,
-			).JSON(w, http.StatusNotFound)
-			return
-		}
 		response.Failed(
 			"Failed Create Product",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -113,27 +131,27 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 // @Tags			Product
 // @Accept			json
 // @Produce			json
+// @Security			BearerAuth
 // @Param			id	path		int		true	"Product ID"
 // @Success			200	{object}	map[string]any
 // @Router			/api/product/{id} [get]
 func (h *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request) {
-	id := r.PathValue("id")
+	id, err := pathID(r, "id", utils.ErrProductNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get product",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
 
 	product, err := h.service.GetProductByID(id)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			response.Failed(
-				"Not Found product",
-				err,
-			).JSON(w, http.StatusNotFound)
-			return
-		}
-
 		response.Failed(
 			"Failed get product",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -149,45 +167,47 @@ func (h *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request)
 // @Tags			Product
 // @Accept			json
 // @Produce		json
+// @Security		BearerAuth
 // @Param			id		path		int					true	"Product ID"
 // @Param			account	body		dto.ProductRequest	true	"Update product"
 // @Success		200		{object}	map[string]any
 // @Router			/api/product/{id} [put]
 func (h *ProductHandler) UpdateProductByID(w http.ResponseWriter, r *http.Request) {
-	id := r.PathValue("id")
+	id, err := pathID(r, "id", utils.ErrProductNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed update product",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
 
 	body, err := request.BindJSON[dto.ProductRequest](r)
 	if err != nil {
 		response.Failed(
 			"Invalid Request",
 			err,
-		).JSON(w, http.StatusBadRequest)
+		).JSON(w, response.Status(err))
+		return
+	}
+	body.ID = id
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
 		return
 	}
 
+	body.UserID = actorID(r)
 	product, err := h.service.UpdateProductByID(id, &body)
 
 	if err != nil {
-		if errors.Is(err, utils.ErrCategoryNotFound) {
-			response.Failed(
-				"Not Found category",
-				err,
-			).JSON(w, http.StatusNotFound)
-			return
-		}
-
-		if errors.Is(err, sql.ErrNoRows) {
-			response.Failed(
-				"Not Found product",
-				err,
-			).JSON(w, http.StatusNotFound)
-			return
-		}
-
 		response.Failed(
 			"Failed update product",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -198,32 +218,61 @@ func (h *ProductHandler) UpdateProductByID(w http.ResponseWriter, r *http.Reques
 	).JSON(w, http.StatusOK)
 }
 
+// @Summary			Scan a barcode
+// @Description		get active product by EAN/UPC barcode
+// @Tags			Product
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			code	path		string		true	"Barcode"
+// @Success			200	{object}	map[string]any
+// @Router			/api/product/barcode/{code} [get]
+func (h *ProductHandler) GetProductByBarcode(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+
+	product, err := h.service.GetProductByBarcode(code)
+
+	if err != nil {
+		response.Failed(
+			"Failed get product",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get product",
+		product,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
 // @Summary			Delete a product
-// @Description		delete product by ID
+// @Description		archive product by ID, sales history is kept
 // @Tags			Product
 // @Accept			json
 // @Produce			json
+// @Security			BearerAuth
 // @Param			id	path		int		true	"Product ID"
 // @Success			200	{object}	map[string]any
 // @Router			/api/product/{id} [delete]
 func (h *ProductHandler) DeleteProductByID(w http.ResponseWriter, r *http.Request) {
-	id := r.PathValue("id")
+	id, err := pathID(r, "id", utils.ErrProductNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed delete product",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
 
-	err := h.service.DeleteProductByID(id)
+	err = h.service.DeleteProductByID(id)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			response.Failed(
-				"Not Found product",
-				err,
-			).JSON(w, http.StatusNotFound)
-			return
-		}
-
 		response.Failed(
 			"Failed delete product",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -233,3 +282,429 @@ func (h *ProductHandler) DeleteProductByID(w http.ResponseWriter, r *http.Reques
 		nil,
 	).JSON(w, http.StatusOK)
 }
+
+// @Summary			Restore a product
+// @Description		restore archived product by ID
+// @Tags			Product
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Product ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/product/{id}/restore [post]
+func (h *ProductHandler) RestoreProductByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrProductNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed restore product",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	product, err := h.service.RestoreProductByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed restore product",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully restore product",
+		product,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary			Show stock movements
+// @Description		get the stock ledger of a product, newest first
+// @Tags			Product
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id			path		string	true	"Product ID"
+// @Param			type		query		string	false	"Filter by movement type"	Enums(sale, refund, receive, adjustment, opname)
+// @Param			page		query		int		false	"Page number"
+// @Param			per_page	query		int		false	"Items per page"
+// @Success			200	{object}	map[string]any
+// @Router			/api/product/{id}/movements [get]
+func (h *ProductHandler) GetStockMovements(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrProductNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get stock movements",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	queryParam := r.URL.Query()
+	page := queryParam.Get("page")
+	perPage := queryParam.Get("per_page")
+	paginate := request.Paginate(page, perPage)
+
+	queryDto := &dto.StockMovementQuery{
+		Type: queryParam.Get("type"),
+	}
+	queryDto.Limit = paginate.Limit
+	queryDto.Offset = paginate.Offset
+
+	if err := queryDto.Validate(); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	movements, total, err := h.service.GetStockMovements(id, queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get stock movements",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get stock movements",
+		movements,
+		&response.Meta{
+			Total: total,
+			Page:  paginate.Page,
+			Limit: paginate.Limit,
+		},
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary			Record stock movement
+// @Description		receive goods or adjust stock of a product, quantity is signed
+// @Tags			Product
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id			path		string						true	"Product ID"
+// @Param			movement	body		dto.StockMovementRequest	true	"Stock movement"
+// @Success			201	{object}	map[string]any
+// @Router			/api/product/{id}/movements [post]
+func (h *ProductHandler) CreateStockMovement(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrProductNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed record stock movement",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body, err := request.BindJSON[dto.StockMovementRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.UserID = actorID(r)
+	movement, err := h.service.CreateStockMovement(id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed record stock movement",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully record stock movement",
+		movement,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary			Show low stock products
+// @Description		get products with stock at or below their reorder point
+// @Tags			Product
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			categoryId	query		string	false	"Filter by category id"
+// @Param			page		query		int		false	"Page number"
+// @Param			per_page	query		int		false	"Items per page"
+// @Success			200	{object}	map[string]any
+// @Router			/api/product/low-stock [get]
+func (h *ProductHandler) LowStockProducts(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	page := queryParam.Get("page")
+	perPage := queryParam.Get("per_page")
+	paginate := request.Paginate(page, perPage)
+
+	queryDto := &dto.LowStockQuery{
+		CategoryID: queryParam.Get("categoryId"),
+	}
+	queryDto.Limit = paginate.Limit
+	queryDto.Offset = paginate.Offset
+
+	if err := queryDto.Validate(); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	products, total, err := h.service.GetLowStockProducts(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get low stock products",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get low stock products",
+		products,
+		&response.Meta{
+			Total: total,
+			Page:  paginate.Page,
+			Limit: paginate.Limit,
+		},
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary			Render product barcode
+// @Description		render EAN-13/EAN-8 or Code128 (for SKU) barcode image of a product
+// @Tags			Product
+// @Produce			png
+// @Produce			image/svg+xml
+// @Security			BearerAuth
+// @Param			id		path		string	true	"Product ID"
+// @Param			code	query		string	false	"Barcode or SKU of the product, default first barcode"
+// @Param			format	query		string	false	"Image format"	Enums(png, svg)
+// @Success			200	{file}	file
+// @Router			/api/product/{id}/barcode [get]
+func (h *ProductHandler) GetBarcodeImage(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrProductNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed render barcode",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	queryParam := r.URL.Query()
+
+	queryDto := &dto.BarcodeQuery{
+		Code:   queryParam.Get("code"),
+		Format: queryParam.Get("format"),
+	}
+	if queryDto.Format == "" {
+		queryDto.Format = dto.BarcodeImagePNG
+	}
+
+	if err := queryDto.Validate(); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	code, err := h.service.GetBarcode(id, queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed render barcode",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if queryDto.Format == dto.BarcodeImageSVG {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Write([]byte(code.SVG(2, 80)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	if err := code.PNG(w, 2, 80); err != nil {
+		log.Printf("render barcode png failed: %v", err)
+	}
+}
+
+// @Summary			Print shelf labels
+// @Description		render a printable HTML sheet of shelf labels (name, price, barcode) for a category or selected products
+// @Tags			Product
+// @Accept			json
+// @Produce			html
+// @Security			BearerAuth
+// @Param			labels	body		dto.LabelRequest	true	"Products to print"
+// @Success			200	{string}	string
+// @Router			/api/product/labels [post]
+func (h *ProductHandler) PrintLabels(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.LabelRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	labels, err := h.service.GetLabels(&body)
+
+	if err != nil {
+		response.Failed(
+			"Failed print labels",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := label.Sheet(w, labels); err != nil {
+		log.Printf("render label sheet failed: %v", err)
+	}
+}
+
+// @Summary			Create product variant
+// @Description		add a variant to a product, the product becomes a variant parent on its first variant
+// @Tags			Product
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id		path		string				true	"Parent product ID"
+// @Param			variant	body		dto.VariantRequest	true	"Add variant"
+// @Success			201		{object}	map[string]any
+// @Router			/api/product/{id}/variants [post]
+func (h *ProductHandler) CreateVariant(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.VariantRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.UserID = actorID(r)
+	id, err := pathID(r, "id", utils.ErrProductNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed create product variant",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	variant, err := h.service.CreateVariant(id, &body)
+	if err != nil {
+		response.Failed(
+			"Failed create product variant",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully create product variant",
+		variant,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary			Update product variant
+// @Description		update a variant's options, SKU, barcodes, price and stock
+// @Tags			Product
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id			path		string				true	"Parent product ID"
+// @Param			variantId	path		string				true	"Variant ID"
+// @Param			variant		body		dto.VariantRequest	true	"Update variant"
+// @Success			200			{object}	map[string]any
+// @Router			/api/product/{id}/variants/{variantId} [put]
+func (h *ProductHandler) UpdateVariant(w http.ResponseWriter, r *http.Request) {
+	variantID, err := pathID(r, "variantId", utils.ErrProductNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed update product variant",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body, err := request.BindJSON[dto.VariantRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+	body.ID = variantID
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.UserID = actorID(r)
+	id, err := pathID(r, "id", utils.ErrProductNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed update product variant",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	variant, err := h.service.UpdateVariant(id, variantID, &body)
+	if err != nil {
+		response.Failed(
+			"Failed update product variant",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully update product variant",
+		variant,
+		nil,
+	).JSON(w, http.StatusOK)
+}
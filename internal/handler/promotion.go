@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+type PromotionHandler struct {
+	service   service.PromotionService
+	validator validator.ValidatePkg
+}
+
+func NewPromotionHandler(srv service.PromotionService, validator validator.ValidatePkg) *PromotionHandler {
+	return &PromotionHandler{
+		service:   srv,
+		validator: validator,
+	}
+}
+
+// @Summary      Show promotions
+// @Description  get list promotion
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 name 		query		string 	false 	"Search by Promotion Name"
+// @Param		 type 		query		string 	false 	"Filter by promotion type"
+// @Param		 is_active 	query		bool 	false 	"Filter by active flag"
+// @Param		 page		query		int		false	"Page number"
+// @Param		 per_page	query		int		false	"Items per page"
+// @Success      200  {object}  map[string]any
+// @Router       /api/promotions [get]
+func (h *PromotionHandler) Promotions(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	page := queryParam.Get("page")
+	perPage := queryParam.Get("per_page")
+	paginate := request.Paginate(page, perPage)
+
+	queryDto := &dto.PromotionQuery{
+		Name: queryParam.Get("name"),
+		Type: queryParam.Get("type"),
+	}
+	queryDto.Limit = paginate.Limit
+	queryDto.Offset = paginate.Offset
+
+	if isActive := queryParam.Get("is_active"); isActive != "" {
+		active, err := strconv.ParseBool(isActive)
+		if err != nil {
+			err = apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid is_active: %w", err))
+			response.Failed(
+				"Invalid Request",
+				err,
+			).JSON(w, response.Status(err))
+			return
+		}
+		queryDto.IsActive = &active
+	}
+
+	promotions, total, err := h.service.GetPromotions(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get promotions",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get promotions",
+		promotions,
+		&response.Meta{
+			Total: total,
+			Page:  paginate.Page,
+			Limit: paginate.Limit,
+		},
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Create promotion
+// @Description  create a promotion
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 promotion	body		dto.PromotionRequest	true	"Add promotion"
+// @Success      201  {object} 			map[string]any
+// @Router       /api/promotions [post]
+func (h *PromotionHandler) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.PromotionRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	promotion, err := h.service.CreatePromotion(&body)
+
+	if err != nil {
+		response.Failed(
+			"Failed create promotion",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully create promotion",
+		promotion,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary			Show a promotion
+// @Description		get promotion by ID
+// @Tags			Promotion
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Promotion ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/promotions/{id} [get]
+func (h *PromotionHandler) GetPromotionByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrPromotionNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get promotion",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	promotion, err := h.service.GetPromotionByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed get promotion",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get promotion",
+		promotion,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary		Update a promotion
+// @Description	Update promotion by ID
+// @Tags			Promotion
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id			path		string					true	"Promotion ID"
+// @Param			promotion	body		dto.PromotionRequest	true	"Update promotion"
+// @Success		200		{object}	map[string]any
+// @Router			/api/promotions/{id} [put]
+func (h *PromotionHandler) UpdatePromotionByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrPromotionNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed update promotion",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body, err := request.BindJSON[dto.PromotionRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	promotion, err := h.service.UpdatePromotionByID(id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed update promotion",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully update promotion",
+		promotion,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary			Delete a promotion
+// @Description		delete promotion by ID
+// @Tags			Promotion
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Promotion ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/promotions/{id} [delete]
+func (h *PromotionHandler) DeletePromotionByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrPromotionNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed delete promotion",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	err = h.service.DeletePromotionByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed delete promotion",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully delete promotion",
+		nil,
+		nil,
+	).JSON(w, http.StatusOK)
+}
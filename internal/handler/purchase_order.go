@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+type PurchaseOrderHandler struct {
+	service   service.PurchaseOrderService
+	validator validator.ValidatePkg
+}
+
+func NewPurchaseOrderHandler(srv service.PurchaseOrderService, validator validator.ValidatePkg) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		service:   srv,
+		validator: validator,
+	}
+}
+
+// @Summary      Show purchase orders
+// @Description  get list purchase order
+// @Tags         Purchase Order
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 status			query		string 	false 	"Filter by status"	Enums(draft, sent, partially_received, closed)
+// @Param		 supplier_id	query		string 	false 	"Filter by supplier id"
+// @Param		 page			query		int		false	"Page number"
+// @Param		 per_page		query		int		false	"Items per page"
+// @Success      200  {object}  map[string]any
+// @Router       /api/purchase-orders [get]
+func (h *PurchaseOrderHandler) PurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	page := queryParam.Get("page")
+	perPage := queryParam.Get("per_page")
+	paginate := request.Paginate(page, perPage)
+
+	queryDto := &dto.PurchaseOrderQuery{
+		Status:     queryParam.Get("status"),
+		SupplierID: queryParam.Get("supplier_id"),
+	}
+	queryDto.Limit = paginate.Limit
+	queryDto.Offset = paginate.Offset
+
+	if err := queryDto.Validate(); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	orders, total, err := h.service.GetPurchaseOrders(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get purchase orders",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get purchase orders",
+		orders,
+		&response.Meta{
+			Total: total,
+			Page:  paginate.Page,
+			Limit: paginate.Limit,
+		},
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Create purchase order
+// @Description  create a draft purchase order with line items and cost prices
+// @Tags         Purchase Order
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 order	body		dto.PurchaseOrderRequest	true	"Add purchase order"
+// @Success      201  {object} 			map[string]any
+// @Router       /api/purchase-orders [post]
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.PurchaseOrderRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.UserID = actorID(r)
+	order, err := h.service.CreatePurchaseOrder(&body)
+
+	if err != nil {
+		response.Failed(
+			"Failed create purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully create purchase order",
+		order,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary			Show a purchase order
+// @Description		get purchase order by ID with items and goods receipts
+// @Tags			Purchase Order
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Purchase Order ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/purchase-orders/{id} [get]
+func (h *PurchaseOrderHandler) GetPurchaseOrderByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrPurchaseOrderNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	order, err := h.service.GetPurchaseOrderByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed get purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get purchase order",
+		order,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary		Update a purchase order
+// @Description	replace supplier, note and items of a draft purchase order
+// @Tags			Purchase Order
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string						true	"Purchase Order ID"
+// @Param			order	body		dto.PurchaseOrderRequest	true	"Update purchase order"
+// @Success		200		{object}	map[string]any
+// @Router			/api/purchase-orders/{id} [put]
+func (h *PurchaseOrderHandler) UpdatePurchaseOrderByID(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.PurchaseOrderRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	id, err := pathID(r, "id", utils.ErrPurchaseOrderNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed update purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	order, err := h.service.UpdatePurchaseOrderByID(id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed update purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully update purchase order",
+		order,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary			Send a purchase order
+// @Description		mark a draft purchase order as sent to the supplier
+// @Tags			Purchase Order
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Purchase Order ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/purchase-orders/{id}/send [post]
+func (h *PurchaseOrderHandler) SendPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrPurchaseOrderNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed send purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	order, err := h.service.SendPurchaseOrder(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed send purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully send purchase order",
+		order,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary			Receive goods
+// @Description		record a partial or full goods receipt, received quantity is added to product stock
+// @Tags			Purchase Order
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id		path		string					true	"Purchase Order ID"
+// @Param			receipt	body		dto.GoodsReceiptRequest	true	"Received items"
+// @Success			200	{object}	map[string]any
+// @Router			/api/purchase-orders/{id}/receive [post]
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.GoodsReceiptRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.UserID = actorID(r)
+	id, err := pathID(r, "id", utils.ErrPurchaseOrderNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed receive purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	order, err := h.service.ReceivePurchaseOrder(id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed receive purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully receive purchase order",
+		order,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary			Close a purchase order
+// @Description		close a purchase order without waiting for the remaining items
+// @Tags			Purchase Order
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Purchase Order ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/purchase-orders/{id}/close [post]
+func (h *PurchaseOrderHandler) ClosePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrPurchaseOrderNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed close purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	order, err := h.service.ClosePurchaseOrder(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed close purchase order",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully close purchase order",
+		order,
+		nil,
+	).JSON(w, http.StatusOK)
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+type RefundHandler struct {
+	service   service.RefundService
+	validator validator.ValidatePkg
+}
+
+func NewRefundHandler(srv service.RefundService, validator validator.ValidatePkg) *RefundHandler {
+	return &RefundHandler{
+		service:   srv,
+		validator: validator,
+	}
+}
+
+// @Summary      Void transaction
+// @Description  void all remaining items of a transaction and restock the products
+// @Tags         Refund
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 id		path		string				true	"Transaction ID"
+// @Param		 void	body		dto.VoidRequest		true	"Void reason"
+// @Success      201  {object} 			map[string]any
+// @Router       /api/transactions/{id}/void [post]
+func (h *RefundHandler) VoidTransaction(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrTransactionNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed void transaction",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body, err := request.BindJSON[dto.VoidRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.UserID = actorID(r)
+	refund, err := h.service.VoidTransaction(id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed void transaction",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully void transaction",
+		refund,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary      Refund transaction
+// @Description  refund part of the items of a transaction and restock the products
+// @Tags         Refund
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 id		path		string				true	"Transaction ID"
+// @Param		 refund	body		dto.RefundRequest	true	"Refund items"
+// @Success      201  {object} 			map[string]any
+// @Router       /api/transactions/{id}/refund [post]
+func (h *RefundHandler) RefundTransaction(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrTransactionNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed refund transaction",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body, err := request.BindJSON[dto.RefundRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.UserID = actorID(r)
+	refund, err := h.service.RefundTransaction(id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed refund transaction",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully refund transaction",
+		refund,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary			Show refunds of a transaction
+// @Description		get refund documents by transaction ID
+// @Tags			Refund
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Transaction ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/transactions/{id}/refunds [get]
+func (h *RefundHandler) GetRefundsByTransactionID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrTransactionNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get refunds",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	refunds, err := h.service.GetRefundsByTransactionID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed get refunds",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get refunds",
+		refunds,
+		nil,
+	).JSON(w, http.StatusOK)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
 	"github.com/Muh-Sidik/kasir-api/internal/service"
 )
 
@@ -23,8 +24,11 @@ func NewReportHandler(reportService service.ReportService) *ReportHandler {
 // @Tags         Report
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param		 start_date 			query		string 	false 	"Start Date"
 // @Param		 end_date 				query		string 	false 	"End Date"
+// @Param		 cashier_id 			query		string 	false 	"Filter by cashier user id"
+// @Param		 group_by 				query		string 	false 	"Group summary by"	Enums(cashier)
 // @Success      200  {object}  map[string]any
 // @Router       /api/report [get]
 func (h *ReportHandler) Report(w http.ResponseWriter, r *http.Request) {
@@ -35,6 +39,8 @@ func (h *ReportHandler) Report(w http.ResponseWriter, r *http.Request) {
 	queryDto := &dto.ReportParam{
 		StartDate: start,
 		EndDate:   end,
+		CashierID: queryParam.Get("cashier_id"),
+		GroupBy:   queryParam.Get("group_by"),
 	}
 
 	topProduct, err := h.reportService.GetReport(queryDto)
@@ -43,7 +49,7 @@ func (h *ReportHandler) Report(w http.ResponseWriter, r *http.Request) {
 		response.Failed(
 			"Failed get top product",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
@@ -53,3 +59,116 @@ func (h *ReportHandler) Report(w http.ResponseWriter, r *http.Request) {
 		nil,
 	).JSON(w, http.StatusOK)
 }
+
+// @Summary      Shift report
+// @Description  get X report (open shift) or Z report (closed shift), cashiers can only see their own shift
+// @Tags         Report
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 id		path		string		true	"Shift ID"
+// @Success      200  {object}  map[string]any
+// @Router       /api/report/shift/{id} [get]
+func (h *ReportHandler) ShiftReport(w http.ResponseWriter, r *http.Request) {
+	actor, ok := actorFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := pathID(r, "id", utils.ErrShiftNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get shift report",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	report, err := h.reportService.GetShiftReport(actor, id)
+
+	if err != nil {
+		response.Failed(
+			"Failed get shift report",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get shift report",
+		report,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Gross margin report
+// @Description  get revenue, cost of goods sold and gross profit grouped by product, category or period
+// @Tags         Report
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 start_date 			query		string 	false 	"Start Date"
+// @Param		 end_date 				query		string 	false 	"End Date"
+// @Param		 group_by 				query		string 	false 	"Group by"	Enums(product, category, day, month)
+// @Success      200  {object}  map[string]any
+// @Router       /api/report/margin [get]
+func (h *ReportHandler) MarginReport(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+
+	queryDto := &dto.MarginReportParam{
+		StartDate: queryParam.Get("start_date"),
+		EndDate:   queryParam.Get("end_date"),
+		GroupBy:   queryParam.Get("group_by"),
+	}
+
+	report, err := h.reportService.GetMarginReport(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get margin report",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get margin report",
+		report,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Modifier sales report
+// @Description  get quantity sold and extra revenue per modifier
+// @Tags         Report
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 start_date 			query		string 	false 	"Start Date"
+// @Param		 end_date 				query		string 	false 	"End Date"
+// @Success      200  {object}  map[string]any
+// @Router       /api/report/modifiers [get]
+func (h *ReportHandler) ModifierReport(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+
+	queryDto := &dto.ModifierReportParam{
+		StartDate: queryParam.Get("start_date"),
+		EndDate:   queryParam.Get("end_date"),
+	}
+
+	report, err := h.reportService.GetModifierReport(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get modifier report",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get modifier report",
+		report,
+		nil,
+	).JSON(w, http.StatusOK)
+}
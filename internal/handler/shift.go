@@ -0,0 +1,328 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+type ShiftHandler struct {
+	service   service.ShiftService
+	validator validator.ValidatePkg
+}
+
+func NewShiftHandler(srv service.ShiftService, validator validator.ValidatePkg) *ShiftHandler {
+	return &ShiftHandler{
+		service:   srv,
+		validator: validator,
+	}
+}
+
+// @Summary      Open shift
+// @Description  open a cashier shift with the opening float in the cash drawer
+// @Tags         Shift
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 X-Terminal-ID	header	string					false	"Terminal/register id of the cash drawer"
+// @Param		 shift			body	dto.OpenShiftRequest	true	"Opening float"
+// @Success      201  {object} 			map[string]any
+// @Router       /api/shifts/open [post]
+func (h *ShiftHandler) OpenShift(w http.ResponseWriter, r *http.Request) {
+	actor, ok := actorFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := request.BindJSON[dto.OpenShiftRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.TerminalID = r.Header.Get("X-Terminal-ID")
+	if len(body.TerminalID) > maxTerminalIDLength {
+		err := apperror.BadRequest("INVALID_TERMINAL_ID", errors.New("X-Terminal-ID must be at most 50 characters"))
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	shift, err := h.service.OpenShift(actor, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed open shift",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully open shift",
+		shift,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary      Current shift
+// @Description  get the open shift of the logged in cashier
+// @Tags         Shift
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]any
+// @Router       /api/shifts/current [get]
+func (h *ShiftHandler) CurrentShift(w http.ResponseWriter, r *http.Request) {
+	actor, ok := actorFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	shift, err := h.service.CurrentShift(actor)
+
+	if err != nil {
+		response.Failed(
+			"Failed get current shift",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get current shift",
+		shift,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Show shifts
+// @Description  get list shift
+// @Tags         Shift
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 cashier_id	query		string 	false 	"Filter by cashier user id"
+// @Param		 status		query		string 	false 	"Filter by status"	Enums(open, closed)
+// @Param		 page		query		int		false	"Page number"
+// @Param		 per_page	query		int		false	"Items per page"
+// @Success      200  {object}  map[string]any
+// @Router       /api/shifts [get]
+func (h *ShiftHandler) Shifts(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	page := queryParam.Get("page")
+	perPage := queryParam.Get("per_page")
+	paginate := request.Paginate(page, perPage)
+
+	queryDto := &dto.ShiftQuery{
+		CashierID: queryParam.Get("cashier_id"),
+		Status:    queryParam.Get("status"),
+	}
+	queryDto.Limit = paginate.Limit
+	queryDto.Offset = paginate.Offset
+
+	if err := queryDto.Validate(); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	shifts, total, err := h.service.GetShifts(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get shifts",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get shifts",
+		shifts,
+		&response.Meta{
+			Total: total,
+			Page:  paginate.Page,
+			Limit: paginate.Limit,
+		},
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Show shift
+// @Description  get shift by ID, cashiers can only see their own shift
+// @Tags         Shift
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 id		path		string		true	"Shift ID"
+// @Success      200  {object}  map[string]any
+// @Router       /api/shifts/{id} [get]
+func (h *ShiftHandler) GetShiftByID(w http.ResponseWriter, r *http.Request) {
+	actor, ok := actorFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := pathID(r, "id", utils.ErrShiftNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get shift",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	shift, err := h.service.GetShiftByID(actor, id)
+
+	if err != nil {
+		response.Failed(
+			"Failed get shift",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get shift",
+		shift,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Record cash movement
+// @Description  record a pay-in or pay-out of the cash drawer during an open shift
+// @Tags         Shift
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 id			path		string					true	"Shift ID"
+// @Param		 movement	body		dto.CashMovementRequest	true	"Pay-in or pay-out"
+// @Success      201  {object} 			map[string]any
+// @Router       /api/shifts/{id}/cash-movements [post]
+func (h *ShiftHandler) AddCashMovement(w http.ResponseWriter, r *http.Request) {
+	actor, ok := actorFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := request.BindJSON[dto.CashMovementRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	id, err := pathID(r, "id", utils.ErrShiftNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed record cash movement",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	movement, err := h.service.AddCashMovement(actor, id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed record cash movement",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully record cash movement",
+		movement,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary      Close shift
+// @Description  close a shift with the counted cash, the variance against expected cash is recorded
+// @Tags         Shift
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 id		path		string					true	"Shift ID"
+// @Param		 shift	body		dto.CloseShiftRequest	true	"Counted cash"
+// @Success      200  {object} 			map[string]any
+// @Router       /api/shifts/{id}/close [post]
+func (h *ShiftHandler) CloseShift(w http.ResponseWriter, r *http.Request) {
+	actor, ok := actorFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := request.BindJSON[dto.CloseShiftRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	id, err := pathID(r, "id", utils.ErrShiftNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed close shift",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	shift, err := h.service.CloseShift(actor, id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed close shift",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully close shift",
+		shift,
+		nil,
+	).JSON(w, http.StatusOK)
+}
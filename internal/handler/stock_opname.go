@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+type StockOpnameHandler struct {
+	service   service.StockOpnameService
+	validator validator.ValidatePkg
+}
+
+func NewStockOpnameHandler(srv service.StockOpnameService, validator validator.ValidatePkg) *StockOpnameHandler {
+	return &StockOpnameHandler{
+		service:   srv,
+		validator: validator,
+	}
+}
+
+// @Summary      Start stock opname
+// @Description  start a physical count session for all products or one category
+// @Tags         Stock Opname
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 opname	body		dto.StockOpnameRequest	true	"Count scope"
+// @Success      201  {object} 			map[string]any
+// @Router       /api/stock-opnames [post]
+func (h *StockOpnameHandler) CreateStockOpname(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.StockOpnameRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.UserID = actorID(r)
+	opname, err := h.service.CreateStockOpname(&body)
+
+	if err != nil {
+		response.Failed(
+			"Failed start stock opname",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully start stock opname",
+		opname,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary      Show stock opnames
+// @Description  get list stock opname session
+// @Tags         Stock Opname
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 status		query		string 	false 	"Filter by status"	Enums(open, posted, cancelled)
+// @Param		 page		query		int		false	"Page number"
+// @Param		 per_page	query		int		false	"Items per page"
+// @Success      200  {object}  map[string]any
+// @Router       /api/stock-opnames [get]
+func (h *StockOpnameHandler) StockOpnames(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	page := queryParam.Get("page")
+	perPage := queryParam.Get("per_page")
+	paginate := request.Paginate(page, perPage)
+
+	queryDto := &dto.StockOpnameQuery{
+		Status: queryParam.Get("status"),
+	}
+	queryDto.Limit = paginate.Limit
+	queryDto.Offset = paginate.Offset
+
+	if err := queryDto.Validate(); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	opnames, total, err := h.service.GetStockOpnames(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get stock opnames",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get stock opnames",
+		opnames,
+		&response.Meta{
+			Total: total,
+			Page:  paginate.Page,
+			Limit: paginate.Limit,
+		},
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Show stock opname
+// @Description  get stock opname with counted items and variance against system stock
+// @Tags         Stock Opname
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 id		path		string		true	"Stock Opname ID"
+// @Success      200  {object}  map[string]any
+// @Router       /api/stock-opnames/{id} [get]
+func (h *StockOpnameHandler) GetStockOpnameByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrStockOpnameNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get stock opname",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	opname, err := h.service.GetStockOpnameByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed get stock opname",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get stock opname",
+		opname,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Submit counts
+// @Description  submit counted quantities, a later count of the same product replaces the previous one
+// @Tags         Stock Opname
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 id		path		string						true	"Stock Opname ID"
+// @Param		 counts	body		dto.StockOpnameCountRequest	true	"Counted quantities"
+// @Success      200  {object} 			map[string]any
+// @Router       /api/stock-opnames/{id}/counts [post]
+func (h *StockOpnameHandler) SubmitCounts(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.StockOpnameCountRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body.UserID = actorID(r)
+	id, err := pathID(r, "id", utils.ErrStockOpnameNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed submit counts",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	opname, err := h.service.SubmitCounts(id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed submit counts",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully submit counts",
+		opname,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Post stock opname
+// @Description  create opname stock movements for every counted item with variance and close the session
+// @Tags         Stock Opname
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 id		path		string		true	"Stock Opname ID"
+// @Success      200  {object}  map[string]any
+// @Router       /api/stock-opnames/{id}/post [post]
+func (h *StockOpnameHandler) PostStockOpname(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrStockOpnameNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed post stock opname",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	opname, err := h.service.PostStockOpname(id, actorID(r))
+
+	if err != nil {
+		response.Failed(
+			"Failed post stock opname",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully post stock opname",
+		opname,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Cancel stock opname
+// @Description  cancel an open stock opname without changing stock
+// @Tags         Stock Opname
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 id		path		string		true	"Stock Opname ID"
+// @Success      200  {object}  map[string]any
+// @Router       /api/stock-opnames/{id}/cancel [post]
+func (h *StockOpnameHandler) CancelStockOpname(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrStockOpnameNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed cancel stock opname",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	opname, err := h.service.CancelStockOpname(id, actorID(r))
+
+	if err != nil {
+		response.Failed(
+			"Failed cancel stock opname",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully cancel stock opname",
+		opname,
+		nil,
+	).JSON(w, http.StatusOK)
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+type SupplierHandler struct {
+	service   service.SupplierService
+	validator validator.ValidatePkg
+}
+
+func NewSupplierHandler(srv service.SupplierService, validator validator.ValidatePkg) *SupplierHandler {
+	return &SupplierHandler{
+		service:   srv,
+		validator: validator,
+	}
+}
+
+// @Summary      Show suppliers
+// @Description  get list supplier
+// @Tags         Supplier
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 name 		query		string 	false 	"Search by Supplier Name"
+// @Param		 page		query		int		false	"Page number"
+// @Param		 per_page	query		int		false	"Items per page"
+// @Success      200  {object}  map[string]any
+// @Router       /api/suppliers [get]
+func (h *SupplierHandler) Suppliers(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	page := queryParam.Get("page")
+	perPage := queryParam.Get("per_page")
+	paginate := request.Paginate(page, perPage)
+
+	queryDto := &dto.SupplierQuery{
+		Name: queryParam.Get("name"),
+	}
+	queryDto.Limit = paginate.Limit
+	queryDto.Offset = paginate.Offset
+
+	suppliers, total, err := h.service.GetSuppliers(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get suppliers",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get suppliers",
+		suppliers,
+		&response.Meta{
+			Total: total,
+			Page:  paginate.Page,
+			Limit: paginate.Limit,
+		},
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Create supplier
+// @Description  create a supplier
+// @Tags         Supplier
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 supplier	body		dto.SupplierRequest	true	"Add supplier"
+// @Success      201  {object} 			map[string]any
+// @Router       /api/suppliers [post]
+func (h *SupplierHandler) CreateSupplier(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.SupplierRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	supplier, err := h.service.CreateSupplier(&body)
+
+	if err != nil {
+		response.Failed(
+			"Failed create supplier",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully create supplier",
+		supplier,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary			Show a supplier
+// @Description		get supplier by ID
+// @Tags			Supplier
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Supplier ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/suppliers/{id} [get]
+func (h *SupplierHandler) GetSupplierByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrSupplierNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get supplier",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	supplier, err := h.service.GetSupplierByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed get supplier",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get supplier",
+		supplier,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary		Update a supplier
+// @Description	Update supplier by ID
+// @Tags			Supplier
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id			path		string				true	"Supplier ID"
+// @Param			supplier	body		dto.SupplierRequest	true	"Update supplier"
+// @Success		200		{object}	map[string]any
+// @Router			/api/suppliers/{id} [put]
+func (h *SupplierHandler) UpdateSupplierByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrSupplierNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed update supplier",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body, err := request.BindJSON[dto.SupplierRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	supplier, err := h.service.UpdateSupplierByID(id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed update supplier",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully update supplier",
+		supplier,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary			Delete a supplier
+// @Description		delete supplier by ID, supplier with purchase orders cannot be deleted
+// @Tags			Supplier
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Supplier ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/suppliers/{id} [delete]
+func (h *SupplierHandler) DeleteSupplierByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrSupplierNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed delete supplier",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	err = h.service.DeleteSupplierByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed delete supplier",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully delete supplier",
+		nil,
+		nil,
+	).JSON(w, http.StatusOK)
+}
@@ -1,21 +1,32 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
 	"github.com/Muh-Sidik/kasir-api/internal/service"
 )
 
+// maxTerminalIDLength mengikuti panjang kolom transactions.terminal_id
+const maxTerminalIDLength = 50
+
 type TransactionHandler struct {
-	service service.TransactionService
+	service   service.TransactionService
+	validator validator.ValidatePkg
 }
 
-func NewTransactionHandler(srv service.TransactionService) *TransactionHandler {
+func NewTransactionHandler(srv service.TransactionService, validator validator.ValidatePkg) *TransactionHandler {
 	return &TransactionHandler{
-		service: srv,
+		service:   srv,
+		validator: validator,
 	}
 }
 
@@ -24,6 +35,9 @@ func NewTransactionHandler(srv service.TransactionService) *TransactionHandler {
 // @Tags         Transaction
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
+// @Param		 Idempotency-Key	header	string				false	"Unique key per cashier to safely retry checkout"
+// @Param		 X-Terminal-ID		header	string				false	"Terminal/register id that records the sale"
 // @Param		 checkout	body		dto.CheckoutRequest	true	"Add checkout"
 // @Success      200  {object} 			map[string]any
 // @Router       /api/checkout [post]
@@ -34,22 +48,166 @@ func (h *TransactionHandler) HandleCheckout(w http.ResponseWriter, r *http.Reque
 		response.Failed(
 			"Invalid Request",
 			err,
-		).JSON(w, http.StatusBadRequest)
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
 		return
 	}
 
-	transaction, err := h.service.CreateCheckout(body.Items)
+	body.CashierID = actorID(r)
+	body.TerminalID = r.Header.Get("X-Terminal-ID")
+	if len(body.TerminalID) > maxTerminalIDLength {
+		err := apperror.BadRequest("INVALID_TERMINAL_ID", errors.New("X-Terminal-ID must be at most 50 characters"))
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	transaction, replayed, err := h.service.CreateCheckout(&body, r.Header.Get("Idempotency-Key"))
 
 	if err != nil {
 		response.Failed(
 			"Failed Create Checkout",
 			err,
-		).JSON(w, http.StatusInternalServerError)
+		).JSON(w, response.Status(err))
 		return
 	}
 
+	if replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
+
 	response.Created(
 		"Successfully create checkout",
 		transaction,
 	).JSON(w, http.StatusCreated)
 }
+
+// @Summary      Show transactions
+// @Description  get list transaction history
+// @Tags         Transaction
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 start_date 	query		string 	false 	"Start Date (YYYY-MM-DD)"
+// @Param		 end_date 		query		string 	false 	"End Date (YYYY-MM-DD)"
+// @Param		 min_amount 	query		int 	false 	"Minimum total amount"
+// @Param		 max_amount 	query		int 	false 	"Maximum total amount"
+// @Param		 product_id 	query		string 	false 	"Filter by product id"
+// @Param		 cashier_id 	query		string 	false 	"Filter by cashier user id"
+// @Param		 terminal_id 	query		string 	false 	"Filter by terminal id"
+// @Param		 shift_id 		query		string 	false 	"Filter by shift id"
+// @Param		 page			query		int		false	"Page number"
+// @Param		 per_page		query		int		false	"Items per page"
+// @Success      200  {object}  map[string]any
+// @Router       /api/transactions [get]
+func (h *TransactionHandler) Transactions(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	page := queryParam.Get("page")
+	perPage := queryParam.Get("per_page")
+	paginate := request.Paginate(page, perPage)
+
+	queryDto := &dto.TransactionQuery{
+		StartDate:  queryParam.Get("start_date"),
+		EndDate:    queryParam.Get("end_date"),
+		ProductID:  queryParam.Get("product_id"),
+		CashierID:  queryParam.Get("cashier_id"),
+		TerminalID: queryParam.Get("terminal_id"),
+		ShiftID:    queryParam.Get("shift_id"),
+	}
+	queryDto.Limit = paginate.Limit
+	queryDto.Offset = paginate.Offset
+
+	for key, target := range map[string]**int64{
+		"min_amount": &queryDto.MinAmount,
+		"max_amount": &queryDto.MaxAmount,
+	} {
+		value := queryParam.Get(key)
+		if value == "" {
+			continue
+		}
+
+		amount, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			err = apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid %s: %w", key, err))
+			response.Failed(
+				"Invalid Request",
+				err,
+			).JSON(w, response.Status(err))
+			return
+		}
+		*target = &amount
+	}
+
+	if err := queryDto.Validate(); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	transactions, total, err := h.service.GetTransactions(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get transactions",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get transactions",
+		transactions,
+		&response.Meta{
+			Total: total,
+			Page:  paginate.Page,
+			Limit: paginate.Limit,
+		},
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary			Show a transaction
+// @Description		get transaction by ID with its details
+// @Tags			Transaction
+// @Accept			json
+// @Produce			json
+// @Security			BearerAuth
+// @Param			id	path		string		true	"Transaction ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/transactions/{id} [get]
+func (h *TransactionHandler) GetTransactionByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrTransactionNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get transaction",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	transaction, err := h.service.GetTransactionByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed get transaction",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get transaction",
+		transaction,
+		nil,
+	).JSON(w, http.StatusOK)
+}
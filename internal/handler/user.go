@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/auth"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+type UserHandler struct {
+	service   service.UserService
+	validator validator.ValidatePkg
+}
+
+func NewUserHandler(srv service.UserService, validator validator.ValidatePkg) *UserHandler {
+	return &UserHandler{
+		service:   srv,
+		validator: validator,
+	}
+}
+
+// @Summary      Show users
+// @Description  get list user
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 search 	query		string 	false 	"Search by username or name"
+// @Param		 role 		query		string 	false 	"Filter by role"
+// @Param		 page		query		int		false	"Page number"
+// @Param		 per_page	query		int		false	"Items per page"
+// @Success      200  {object}  map[string]any
+// @Router       /api/users [get]
+func (h *UserHandler) Users(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	page := queryParam.Get("page")
+	perPage := queryParam.Get("per_page")
+	paginate := request.Paginate(page, perPage)
+
+	queryDto := &dto.UserQuery{
+		Search: queryParam.Get("search"),
+		Role:   queryParam.Get("role"),
+	}
+	queryDto.Limit = paginate.Limit
+	queryDto.Offset = paginate.Offset
+
+	users, total, err := h.service.GetUsers(queryDto)
+
+	if err != nil {
+		response.Failed(
+			"Failed get users",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get users",
+		users,
+		&response.Meta{
+			Total: total,
+			Page:  paginate.Page,
+			Limit: paginate.Limit,
+		},
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary      Create user
+// @Description  create a user account
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param		 user	body		dto.CreateUserRequest	true	"Add user"
+// @Success      201  {object} 			map[string]any
+// @Router       /api/users [post]
+func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	body, err := request.BindJSON[dto.CreateUserRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	user, err := h.service.CreateUser(&body)
+
+	if err != nil {
+		response.Failed(
+			"Failed create user",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.Created(
+		"Successfully create user",
+		user,
+	).JSON(w, http.StatusCreated)
+}
+
+// @Summary			Show a user
+// @Description		get user by ID
+// @Tags			User
+// @Accept			json
+// @Produce			json
+// @Security		BearerAuth
+// @Param			id	path		string		true	"User ID"
+// @Success			200	{object}	map[string]any
+// @Router			/api/users/{id} [get]
+func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrUserNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed get user",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	user, err := h.service.GetUserByID(id)
+
+	if err != nil {
+		response.Failed(
+			"Failed get user",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully get user",
+		user,
+		nil,
+	).JSON(w, http.StatusOK)
+}
+
+// @Summary		Update a user
+// @Description	Update name, role, active flag or password of a user
+// @Tags			User
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string					true	"User ID"
+// @Param			user	body		dto.UpdateUserRequest	true	"Update user"
+// @Success		200		{object}	map[string]any
+// @Router			/api/users/{id} [put]
+func (h *UserHandler) UpdateUserByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id", utils.ErrUserNotFound)
+	if err != nil {
+		response.Failed(
+			"Failed update user",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	body, err := request.BindJSON[dto.UpdateUserRequest](r)
+	if err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	if err := h.validator.ValidateWithLang(body, request.Language(r)); err != nil {
+		response.Failed(
+			"Invalid Request",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	var actorID string
+	if claims, ok := auth.ClaimsFromContext(r.Context()); ok {
+		actorID = claims.Subject
+	}
+
+	user, err := h.service.UpdateUserByID(actorID, id, &body)
+
+	if err != nil {
+		response.Failed(
+			"Failed update user",
+			err,
+		).JSON(w, response.Status(err))
+		return
+	}
+
+	response.OK(
+		"Successfully update user",
+		user,
+		nil,
+	).JSON(w, http.StatusOK)
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/auth"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+)
+
+type Auth struct {
+	jwt *auth.JWT
+}
+
+func NewAuth(e *config.Env) *Auth {
+	return &Auth{
+		jwt: auth.NewJWT(e.JWT_SECRET, e.JWT_ACCESS_TTL),
+	}
+}
+
+// Require memastikan request membawa access token yang valid dengan role minimal role,
+// claims user yang login disimpan di context request
+func (a *Auth) Require(role string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			response.Failed("Unauthorized", utils.ErrUnauthenticated).JSON(w, http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := a.jwt.Parse(strings.TrimSpace(token))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			response.Failed("Unauthorized", err).JSON(w, response.Status(err))
+			return
+		}
+
+		if !model.RoleAllows(claims.Role, role) {
+			response.Failed("Forbidden", utils.ErrForbidden).JSON(w, http.StatusForbidden)
+			return
+		}
+
+		next(w, r.WithContext(auth.WithClaims(r.Context(), claims)))
+	}
+}
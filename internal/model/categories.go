@@ -10,6 +10,9 @@ type Categories struct {
 	ID          uuid.UUID       `sql:"id" json:"id"`
 	Name        string    `sql:"name" json:"name"`
 	Description string    `sql:"description" json:"description"`
+	TaxExempt   bool      `sql:"tax_exempt" json:"tax_exempt"`
 	CreatedAt   time.Time `sql:"created_at" json:"created_at"`
 	UpdatedAt   time.Time `sql:"updated_at" json:"updated_at"`
+	// DeletedAt terisi jika kategori diarsipkan
+	DeletedAt   *time.Time `sql:"deleted_at" json:"deleted_at"`
 }
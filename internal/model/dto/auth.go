@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+
+type LoginRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type UserQuery struct {
+	Search string
+	Role   string
+	request.PaginateQuery
+}
+
+type CreateUserRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=50,alphanum"`
+	Name     string `json:"name" validate:"required,min=3,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+	Role     string `json:"role" validate:"required,oneof=cashier manager admin"`
+}
+
+// UpdateUserRequest tidak mengubah password jika password kosong
+type UpdateUserRequest struct {
+	Name     string `json:"name" validate:"required,min=3,max=255"`
+	Password string `json:"password" validate:"omitempty,min=8,max=72"`
+	Role     string `json:"role" validate:"required,oneof=cashier manager admin"`
+	IsActive bool   `json:"is_active"`
+}
@@ -3,4 +3,5 @@ package dto
 type CategoryRequest struct {
 	Name        string `json:"name" validate:"required,min=3"`
 	Description string `json:"description" validate:"required"`
+	TaxExempt   bool   `json:"tax_exempt"`
 }
@@ -3,10 +3,25 @@ package dto
 import "github.com/gofrs/uuid/v5"
 
 type CheckoutRequest struct {
-	Items []CheckoutItem `json:"items"`
+	Items     []CheckoutItem    `json:"items" validate:"required,min=1,dive"`
+	Payments  []CheckoutPayment `json:"payments" validate:"required,min=1,dive"`
+	PromoCode string            `json:"promo_code" validate:"omitempty,max=50"`
+	// CashierID diisi dari user yang login, TerminalID dari header X-Terminal-ID
+	CashierID  *uuid.UUID `json:"-"`
+	TerminalID string     `json:"-"`
 }
 
+// CheckoutItem diisi product_id atau barcode hasil scan, product_id dipakai jika keduanya ada
 type CheckoutItem struct {
-	ProductID uuid.UUID `json:"product_id"`
-	Quantity  int       `json:"quantity"`
+	ProductID uuid.UUID `json:"product_id" validate:"required_without=Barcode"`
+	Barcode   string    `json:"barcode" validate:"omitempty,max=14"`
+	Quantity  int       `json:"quantity" validate:"qty"`
+	// Modifiers adalah id modifier yang dipilih, harganya ditambahkan ke harga satuan item
+	Modifiers []uuid.UUID `json:"modifiers" validate:"omitempty,max=20,unique"`
+}
+
+type CheckoutPayment struct {
+	Method    string `json:"method" validate:"required,oneof=cash card qris e_wallet voucher"`
+	Amount    int64  `json:"amount" validate:"money"`
+	Reference string `json:"reference" validate:"max=100"`
 }
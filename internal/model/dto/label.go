@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+)
+
+const (
+	BarcodeImagePNG = "png"
+	BarcodeImageSVG = "svg"
+)
+
+// BarcodeQuery memilih kode yang digambar, kosong berarti barcode pertama atau SKU produk
+type BarcodeQuery struct {
+	Code   string
+	Format string
+}
+
+func (q *BarcodeQuery) Validate() error {
+	switch q.Format {
+	case BarcodeImagePNG, BarcodeImageSVG:
+		return nil
+	}
+
+	return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid format %q", q.Format))
+}
+
+// LabelRequest memilih produk yang dicetak labelnya, per kategori atau daftar product_ids
+type LabelRequest struct {
+	CategoryID string   `json:"category_id" validate:"required_without=ProductIDs,omitempty,uuid"`
+	ProductIDs []string `json:"product_ids" validate:"omitempty,max=200,dive,uuid"`
+	// Copies adalah jumlah label per produk, default 1
+	Copies int `json:"copies" validate:"omitempty,min=1,max=50"`
+}
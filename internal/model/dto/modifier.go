@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/gofrs/uuid/v5"
+)
+
+type ModifierGroupQuery struct {
+	Name string
+	// ProductID menampilkan group yang berlaku untuk produk, termasuk lewat kategori dan induknya
+	ProductID string
+	request.PaginateQuery
+}
+
+func (q *ModifierGroupQuery) Validate() error {
+	if q.ProductID != "" {
+		if _, err := uuid.FromString(q.ProductID); err != nil {
+			return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid product_id %q", q.ProductID))
+		}
+	}
+
+	return nil
+}
+
+type ModifierGroupRequest struct {
+	Name      string `json:"name" validate:"required,min=2,max=100"`
+	MinSelect int    `json:"min_select" validate:"min=0,max=20"`
+	MaxSelect int    `json:"max_select" validate:"required,min=1,max=20,gtefield=MinSelect"`
+	// Modifiers diganti seluruhnya saat update, kirim id modifier lama untuk mempertahankan id-nya
+	Modifiers   []ModifierRequest `json:"modifiers" validate:"required,min=1,max=30,unique=Name,dive"`
+	ProductIDs  []uuid.UUID       `json:"product_ids" validate:"omitempty,max=200,unique"`
+	CategoryIDs []uuid.UUID       `json:"category_ids" validate:"omitempty,max=50,unique"`
+}
+
+type ModifierRequest struct {
+	ID         *uuid.UUID `json:"id"`
+	Name       string     `json:"name" validate:"required,max=100"`
+	PriceDelta int64      `json:"price_delta" validate:"min=0,max=1000000000"`
+}
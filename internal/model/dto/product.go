@@ -1,16 +1,39 @@
 package dto
 
-import "github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+import (
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/gofrs/uuid/v5"
+)
 
 type ProductQuery struct {
+	// Name dicari di nama produk, juga dicocokkan persis dengan SKU dan barcode
 	Name       string `json:"name" validate:"required,min=3"`
 	CategoryID string `json:"category_id" validate:"required,uuid"`
+	// IncludeArchived ikut menampilkan produk yang sudah diarsipkan
+	IncludeArchived bool `json:"include_archived"`
 	request.PaginateQuery
 }
 
 type ProductRequest struct {
-	Name       string `json:"name" validate:"required,min=3"`
-	Price      int    `json:"price" validate:"required,numeric"`
-	Stock      int    `json:"stock" validate:"required,min=0,numeric"`
-	CategoryID string `json:"category_id" validate:"required,uuid"`
+	// ID diisi dari path saat update supaya rule unique tidak bentrok dengan data sendiri
+	ID   string `json:"-"`
+	Name string `json:"name" validate:"required,min=3,unique_product_name"`
+	SKU  string `json:"sku" validate:"omitempty,max=64,unique_sku"`
+	// Barcodes adalah daftar EAN/UPC. Saat update, kosongkan field untuk tidak mengubah
+	// barcode dan kirim [] untuk menghapus semua barcode
+	Barcodes   []string `json:"barcodes" validate:"omitempty,max=10,unique,dive,barcode,unique_barcode"`
+	Price      int      `json:"price" validate:"money"`
+	CategoryID string   `json:"category_id" validate:"required,uuid,category_exists"`
+	// Stock adalah stok awal saat create. Saat update, stock yang dikirim dianggap hasil hitung
+	// dan selisihnya dicatat sebagai movement adjustment, kosongkan untuk tidak mengubah stok
+	Stock *int `json:"stock" validate:"omitempty,min=0,max=1000000"`
+	// Cost adalah HPP per unit. Selanjutnya diperbarui otomatis dari penerimaan PO,
+	// kosongkan saat update untuk mempertahankan HPP sekarang
+	Cost *int64 `json:"cost" validate:"omitempty,min=0,max=1000000000"`
+	// ReorderPoint adalah batas stok minimum, kirim 0 jika produk tidak perlu dipantau.
+	// Kosongkan saat update untuk tidak mengubah
+	ReorderPoint    *int `json:"reorder_point" validate:"omitempty,min=0,max=1000000"`
+	ReorderQuantity int  `json:"reorder_quantity" validate:"min=0,max=1000000"`
+	// UserID diisi dari user yang login
+	UserID *uuid.UUID `json:"-"`
 }
@@ -0,0 +1,28 @@
+package dto
+
+import "github.com/gofrs/uuid/v5"
+
+type VariantOptionRequest struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	Value string `json:"value" validate:"required,max=100"`
+}
+
+// VariantRequest membuat atau mengubah satu varian. Nama varian dibentuk dari nama produk
+// induk dan nilai opsi, kategorinya selalu mengikuti produk induk
+type VariantRequest struct {
+	// ID diisi dari path saat update supaya rule unique tidak bentrok dengan data sendiri
+	ID string `json:"-"`
+	// Options misalnya [{"name":"Ukuran","value":"L"}], nama opsi harus sama dan berurutan
+	// sama dengan varian lain di produk induk yang sama
+	Options  []VariantOptionRequest `json:"options" validate:"required,min=1,max=3,unique=Name,dive"`
+	SKU      string                 `json:"sku" validate:"omitempty,max=64,unique_sku"`
+	Barcodes []string               `json:"barcodes" validate:"omitempty,max=10,unique,dive,barcode,unique_barcode"`
+	Price    int                    `json:"price" validate:"money"`
+	// Stock, Cost dan ReorderPoint mengikuti aturan yang sama dengan ProductRequest
+	Stock           *int   `json:"stock" validate:"omitempty,min=0,max=1000000"`
+	Cost            *int64 `json:"cost" validate:"omitempty,min=0,max=1000000000"`
+	ReorderPoint    *int   `json:"reorder_point" validate:"omitempty,min=0,max=1000000"`
+	ReorderQuantity int    `json:"reorder_quantity" validate:"min=0,max=1000000"`
+	// UserID diisi dari user yang login
+	UserID *uuid.UUID `json:"-"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+)
+
+type PromotionQuery struct {
+	Name     string
+	Type     string
+	IsActive *bool
+	request.PaginateQuery
+}
+
+type PromotionRequest struct {
+	Name           string    `json:"name" validate:"required,min=3"`
+	Type           string    `json:"type" validate:"required,oneof=item_percentage item_nominal buy_x_get_y bundle_price cart_percentage cart_nominal"`
+	Code           string    `json:"code" validate:"omitempty,max=50"`
+	ProductID      string    `json:"product_id" validate:"omitempty,uuid"`
+	Value          int64     `json:"value" validate:"min=0"`
+	BuyQuantity    int       `json:"buy_quantity" validate:"min=0"`
+	GetQuantity    int       `json:"get_quantity" validate:"min=0"`
+	BundleQuantity int       `json:"bundle_quantity" validate:"min=0"`
+	BundlePrice    int64     `json:"bundle_price" validate:"min=0"`
+	MinPurchase    int64     `json:"min_purchase" validate:"min=0"`
+	MaxDiscount    int64     `json:"max_discount" validate:"min=0"`
+	StartAt        time.Time `json:"start_at" validate:"required"`
+	EndAt          time.Time `json:"end_at" validate:"required,gtfield=StartAt"`
+	IsActive       bool      `json:"is_active"`
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/gofrs/uuid/v5"
+)
+
+type PurchaseOrderRequest struct {
+	SupplierID uuid.UUID           `json:"supplier_id" validate:"required"`
+	Note       string              `json:"note" validate:"max=255"`
+	Items      []PurchaseOrderItem `json:"items" validate:"required,min=1,max=200,unique=ProductID,dive"`
+	// UserID diisi dari user yang login
+	UserID *uuid.UUID `json:"-"`
+}
+
+// PurchaseOrderItem: UnitCost adalah harga beli per unit dari supplier
+type PurchaseOrderItem struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1,max=100000"`
+	UnitCost  int64     `json:"unit_cost" validate:"min=0,max=1000000000"`
+}
+
+type GoodsReceiptRequest struct {
+	Note  string             `json:"note" validate:"max=255"`
+	Items []GoodsReceiptItem `json:"items" validate:"required,min=1,max=200,unique=ProductID,dive"`
+	// UserID diisi dari user yang login
+	UserID *uuid.UUID `json:"-"`
+}
+
+type GoodsReceiptItem struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1,max=100000"`
+}
+
+type PurchaseOrderQuery struct {
+	Status     string
+	SupplierID string
+	request.PaginateQuery
+}
+
+func (q *PurchaseOrderQuery) Validate() error {
+	switch q.Status {
+	case "", "draft", "sent", "partially_received", "closed":
+	default:
+		return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid status %q, allowed: draft, sent, partially_received, closed", q.Status))
+	}
+
+	if q.SupplierID != "" {
+		if _, err := uuid.FromString(q.SupplierID); err != nil {
+			return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid supplier_id %q", q.SupplierID))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/gofrs/uuid/v5"
+
+type VoidRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+	// UserID diisi dari user yang login
+	UserID *uuid.UUID `json:"-"`
+}
+
+type RefundRequest struct {
+	Reason string       `json:"reason" validate:"required,max=255"`
+	Items  []RefundItem `json:"items" validate:"required,min=1,dive"`
+	// UserID diisi dari user yang login
+	UserID *uuid.UUID `json:"-"`
+}
+
+type RefundItem struct {
+	TransactionDetailID uuid.UUID `json:"transaction_detail_id" validate:"required"`
+	Quantity            int       `json:"quantity" validate:"qty"`
+}
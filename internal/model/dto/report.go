@@ -1,13 +1,41 @@
 package dto
 
 import (
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/gofrs/uuid/v5"
 )
 
+// ReportGroupByCashier menambahkan ringkasan per kasir pada report
+const ReportGroupByCashier = "cashier"
+
 type ReportParam struct {
 	StartDate string
 	EndDate   string
+	CashierID string
+	GroupBy   string
+}
+
+// Validate memastikan tanggal, cashier_id dan group_by valid
+func (p *ReportParam) Validate() error {
+	if _, _, err := p.ParseDates(); err != nil {
+		return err
+	}
+
+	if p.CashierID != "" {
+		if _, err := uuid.FromString(p.CashierID); err != nil {
+			return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid cashier_id: %w", err))
+		}
+	}
+
+	if p.GroupBy != "" && p.GroupBy != ReportGroupByCashier {
+		return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid group_by %q, allowed: %s", p.GroupBy, ReportGroupByCashier))
+	}
+
+	return nil
 }
 
 func (p *ReportParam) ParseDates() (time.Time, time.Time, error) {
@@ -17,7 +45,7 @@ func (p *ReportParam) ParseDates() (time.Time, time.Time, error) {
 	if p.StartDate != "" {
 		startDate, err = time.Parse("2006-01-02", p.StartDate)
 		if err != nil {
-			return time.Time{}, time.Time{}, fmt.Errorf("invalid start_date format: %w", err)
+			return time.Time{}, time.Time{}, apperror.BadRequest("INVALID_DATE", fmt.Errorf("invalid start_date format: %w", err))
 		}
 	} else {
 		startDate = time.Now().Truncate(24 * time.Hour)
@@ -26,7 +54,7 @@ func (p *ReportParam) ParseDates() (time.Time, time.Time, error) {
 	if p.EndDate != "" {
 		endDate, err = time.Parse("2006-01-02", p.EndDate)
 		if err != nil {
-			return time.Time{}, time.Time{}, fmt.Errorf("invalid end_date format: %w", err)
+			return time.Time{}, time.Time{}, apperror.BadRequest("INVALID_DATE", fmt.Errorf("invalid end_date format: %w", err))
 		}
 	} else {
 		endDate = time.Now().Truncate(24 * time.Hour)
@@ -34,8 +62,51 @@ func (p *ReportParam) ParseDates() (time.Time, time.Time, error) {
 
 	// Validasi: start_date tidak boleh setelah end_date
 	if startDate.After(endDate) {
-		return time.Time{}, time.Time{}, fmt.Errorf("start_date cannot be after end_date")
+		return time.Time{}, time.Time{}, apperror.BadRequest("INVALID_DATE_RANGE", errors.New("start_date cannot be after end_date"))
 	}
 
 	return startDate, endDate, nil
 }
+
+const (
+	MarginGroupByProduct  = "product"
+	MarginGroupByCategory = "category"
+	MarginGroupByDay      = "day"
+	MarginGroupByMonth    = "month"
+)
+
+type MarginReportParam struct {
+	StartDate string
+	EndDate   string
+	GroupBy   string
+}
+
+// Validate memastikan tanggal valid dan mengisi group_by default per produk
+func (p *MarginReportParam) Validate() error {
+	dates := ReportParam{StartDate: p.StartDate, EndDate: p.EndDate}
+	if _, _, err := dates.ParseDates(); err != nil {
+		return err
+	}
+
+	switch p.GroupBy {
+	case "":
+		p.GroupBy = MarginGroupByProduct
+	case MarginGroupByProduct, MarginGroupByCategory, MarginGroupByDay, MarginGroupByMonth:
+	default:
+		return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid group_by %q, allowed: product, category, day, month", p.GroupBy))
+	}
+
+	return nil
+}
+
+type ModifierReportParam struct {
+	StartDate string
+	EndDate   string
+}
+
+// Validate memastikan rentang tanggal valid
+func (p *ModifierReportParam) Validate() error {
+	dates := ReportParam{StartDate: p.StartDate, EndDate: p.EndDate}
+	_, _, err := dates.ParseDates()
+	return err
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/gofrs/uuid/v5"
+)
+
+type OpenShiftRequest struct {
+	OpeningFloat int64  `json:"opening_float" validate:"min=0,max=999999999999"`
+	Note         string `json:"note" validate:"max=255"`
+	// TerminalID diisi dari header X-Terminal-ID
+	TerminalID string `json:"-"`
+}
+
+type CloseShiftRequest struct {
+	CountedCash int64  `json:"counted_cash" validate:"min=0,max=999999999999"`
+	Note        string `json:"note" validate:"max=255"`
+}
+
+type CashMovementRequest struct {
+	Type   string `json:"type" validate:"required,oneof=pay_in pay_out"`
+	Amount int64  `json:"amount" validate:"money"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type ShiftQuery struct {
+	CashierID string
+	Status    string
+	request.PaginateQuery
+}
+
+func (q *ShiftQuery) Validate() error {
+	if q.CashierID != "" {
+		if _, err := uuid.FromString(q.CashierID); err != nil {
+			return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid cashier_id: %w", err))
+		}
+	}
+
+	if q.Status != "" && q.Status != "open" && q.Status != "closed" {
+		return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid status %q, allowed: open, closed", q.Status))
+	}
+
+	return nil
+}
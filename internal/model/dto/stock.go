@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/gofrs/uuid/v5"
+)
+
+// StockMovementRequest dipakai untuk penerimaan barang dan koreksi stok manual.
+// Quantity bertanda, negatif untuk mengurangi stok (contoh barang rusak)
+type StockMovementRequest struct {
+	Type     string `json:"type" validate:"required,oneof=receive adjustment"`
+	Quantity int    `json:"quantity" validate:"required,min=-10000,max=10000"`
+	Reason   string `json:"reason" validate:"required,max=255"`
+	// UserID diisi dari user yang login
+	UserID *uuid.UUID `json:"-"`
+}
+
+type StockMovementQuery struct {
+	Type string
+	request.PaginateQuery
+}
+
+func (q *StockMovementQuery) Validate() error {
+	switch q.Type {
+	case "", "sale", "refund", "receive", "adjustment", "opname":
+		return nil
+	}
+
+	return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid type %q", q.Type))
+}
+
+type LowStockQuery struct {
+	CategoryID string
+	request.PaginateQuery
+}
+
+func (q *LowStockQuery) Validate() error {
+	if q.CategoryID == "" {
+		return nil
+	}
+
+	if _, err := uuid.FromString(q.CategoryID); err != nil {
+		return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid category_id %q", q.CategoryID))
+	}
+
+	return nil
+}
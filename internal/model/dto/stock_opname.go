@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/gofrs/uuid/v5"
+)
+
+type StockOpnameRequest struct {
+	// CategoryID kosong berarti semua produk
+	CategoryID *uuid.UUID `json:"category_id"`
+	Note       string     `json:"note" validate:"max=255"`
+	// UserID diisi dari user yang login
+	UserID *uuid.UUID `json:"-"`
+}
+
+type StockOpnameCountRequest struct {
+	Items []StockOpnameCountItem `json:"items" validate:"required,min=1,max=500,dive"`
+	// UserID diisi dari user yang login
+	UserID *uuid.UUID `json:"-"`
+}
+
+// StockOpnameCountItem mengganti hasil hitung sebelumnya dari penghitung yang sama untuk
+// produk yang sama, hitungan penghitung lain tetap dan ikut dijumlahkan
+type StockOpnameCountItem struct {
+	ProductID       uuid.UUID `json:"product_id" validate:"required"`
+	CountedQuantity int       `json:"counted_quantity" validate:"min=0,max=1000000"`
+}
+
+type StockOpnameQuery struct {
+	Status string
+	request.PaginateQuery
+}
+
+func (q *StockOpnameQuery) Validate() error {
+	switch q.Status {
+	case "", "open", "posted", "cancelled":
+		return nil
+	}
+
+	return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid status %q, allowed: open, posted, cancelled", q.Status))
+}
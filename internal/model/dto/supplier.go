@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+
+type SupplierQuery struct {
+	Name string
+	request.PaginateQuery
+}
+
+type SupplierRequest struct {
+	Name        string `json:"name" validate:"required,min=3,max=100"`
+	ContactName string `json:"contact_name" validate:"max=100"`
+	Phone       string `json:"phone" validate:"omitempty,phone_id"`
+	Email       string `json:"email" validate:"omitempty,email,max=100"`
+	Address     string `json:"address" validate:"max=255"`
+}
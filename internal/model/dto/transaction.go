@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/gofrs/uuid/v5"
+)
+
+type TransactionQuery struct {
+	StartDate  string
+	EndDate    string
+	MinAmount  *int64
+	MaxAmount  *int64
+	ProductID  string
+	CashierID  string
+	TerminalID string
+	ShiftID    string
+	request.PaginateQuery
+}
+
+// Validate memastikan format tanggal dan rentang nominal valid.
+// Berbeda dengan ReportParam, tanggal kosong berarti tanpa batas.
+func (q *TransactionQuery) Validate() error {
+	var startDate, endDate time.Time
+	var err error
+
+	if q.StartDate != "" {
+		startDate, err = time.Parse("2006-01-02", q.StartDate)
+		if err != nil {
+			return apperror.BadRequest("INVALID_DATE", fmt.Errorf("invalid start_date format: %w", err))
+		}
+	}
+
+	if q.EndDate != "" {
+		endDate, err = time.Parse("2006-01-02", q.EndDate)
+		if err != nil {
+			return apperror.BadRequest("INVALID_DATE", fmt.Errorf("invalid end_date format: %w", err))
+		}
+	}
+
+	if q.StartDate != "" && q.EndDate != "" && startDate.After(endDate) {
+		return apperror.BadRequest("INVALID_DATE_RANGE", errors.New("start_date cannot be after end_date"))
+	}
+
+	if q.ProductID != "" {
+		if _, err := uuid.FromString(q.ProductID); err != nil {
+			return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid product_id: %w", err))
+		}
+	}
+
+	if q.CashierID != "" {
+		if _, err := uuid.FromString(q.CashierID); err != nil {
+			return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid cashier_id: %w", err))
+		}
+	}
+
+	if q.ShiftID != "" {
+		if _, err := uuid.FromString(q.ShiftID); err != nil {
+			return apperror.BadRequest("INVALID_QUERY", fmt.Errorf("invalid shift_id: %w", err))
+		}
+	}
+
+	if q.MinAmount != nil && q.MaxAmount != nil && *q.MinAmount > *q.MaxAmount {
+		return apperror.BadRequest("INVALID_AMOUNT_RANGE", errors.New("min_amount cannot be greater than max_amount"))
+	}
+
+	return nil
+}
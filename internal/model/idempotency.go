@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// IdempotencyKey unik per kasir, kasir lain boleh memakai key yang sama
+type IdempotencyKey struct {
+	CashierID     uuid.UUID `sql:"cashier_id" json:"cashier_id"`
+	Key           string    `sql:"key" json:"key"`
+	RequestHash   string    `sql:"request_hash" json:"request_hash"`
+	TransactionID uuid.UUID `sql:"transaction_id" json:"transaction_id"`
+	Response      []byte    `sql:"response" json:"-"`
+	CreatedAt     time.Time `sql:"created_at" json:"created_at"`
+}
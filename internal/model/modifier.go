@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ModifierGroup adalah kumpulan pilihan tambahan untuk item, misalnya "Susu" atau "Extra".
+// Group berlaku untuk produk yang dipasang langsung, varian dari produk tersebut,
+// dan semua produk di kategori yang dipasang. MinSelect 0 berarti opsional
+type ModifierGroup struct {
+	ID          uuid.UUID   `sql:"id" json:"id"`
+	Name        string      `sql:"name" json:"name"`
+	MinSelect   int         `sql:"min_select" json:"min_select"`
+	MaxSelect   int         `sql:"max_select" json:"max_select"`
+	Modifiers   []Modifier  `json:"modifiers"`
+	ProductIDs  []uuid.UUID `json:"product_ids,omitempty"`
+	CategoryIDs []uuid.UUID `json:"category_ids,omitempty"`
+	CreatedAt   time.Time   `sql:"created_at" json:"created_at"`
+	UpdatedAt   time.Time   `sql:"updated_at" json:"updated_at"`
+}
+
+// Modifier: PriceDelta adalah tambahan harga per unit item, 0 untuk pilihan gratis
+type Modifier struct {
+	ID         uuid.UUID `sql:"id" json:"id"`
+	Name       string    `sql:"name" json:"name"`
+	PriceDelta int64     `sql:"price_delta" json:"price_delta"`
+}
+
+// TransactionDetailModifier adalah snapshot modifier yang dipilih untuk satu item transaksi
+type TransactionDetailModifier struct {
+	ModifierID uuid.UUID `sql:"modifier_id" json:"modifier_id"`
+	GroupName  string    `sql:"group_name" json:"group_name"`
+	Name       string    `sql:"name" json:"name"`
+	PriceDelta int64     `sql:"price_delta" json:"price_delta"`
+}
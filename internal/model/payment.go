@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	PaymentMethodCash    = "cash"
+	PaymentMethodCard    = "card"
+	PaymentMethodQRIS    = "qris"
+	PaymentMethodEWallet = "e_wallet"
+	PaymentMethodVoucher = "voucher"
+)
+
+var PaymentMethods = []string{
+	PaymentMethodCash,
+	PaymentMethodCard,
+	PaymentMethodQRIS,
+	PaymentMethodEWallet,
+	PaymentMethodVoucher,
+}
+
+type TransactionPayment struct {
+	ID            uuid.UUID `sql:"id" json:"id"`
+	TransactionID uuid.UUID `sql:"transaction_id" json:"transaction_id"`
+	Method        string    `sql:"method" json:"method"`
+	Amount        int64     `sql:"amount" json:"amount"`
+	ChangeAmount  int64     `sql:"change_amount" json:"change_amount"`
+	Reference     string    `sql:"reference" json:"reference,omitempty"`
+	CreatedAt     time.Time `sql:"created_at" json:"created_at"`
+}
@@ -9,9 +9,23 @@ import (
 type Product struct {
 	ID         uuid.UUID       `sql:"id" json:"id"`
 	Name       string    `sql:"name" json:"name"`
+	SKU        *string   `sql:"sku" json:"sku"`
+	// Barcodes kosong (nil) saat update berarti barcode tidak diubah
+	Barcodes   []string  `json:"barcodes"`
 	Price      int       `sql:"price" json:"price"`
+	// Cost adalah HPP rata-rata bergerak per unit
+	Cost       int64     `sql:"cost" json:"cost"`
 	Stock      int       `sql:"stock" json:"stock"`
+	// ReorderPoint kosong berarti produk tidak dipantau stok minimumnya
+	ReorderPoint    *int `sql:"reorder_point" json:"reorder_point"`
+	ReorderQuantity int  `sql:"reorder_quantity" json:"reorder_quantity"`
 	CategoryID uuid.UUID    `sql:"category_id" json:"category_id"`
+	// ParentID terisi jika produk ini varian, HasVariants jika produk ini induk varian
+	ParentID    *uuid.UUID `sql:"parent_id" json:"parent_id"`
+	HasVariants bool       `sql:"has_variants" json:"has_variants"`
+	Options     []VariantOption `json:"options,omitempty"`
 	CreatedAt  time.Time `sql:"created_at" json:"created_at"`
 	UpdatedAt  time.Time `sql:"updated_at" json:"updated_at"`
+	// DeletedAt terisi jika produk diarsipkan
+	DeletedAt  *time.Time `sql:"deleted_at" json:"deleted_at"`
 }
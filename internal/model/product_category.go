@@ -7,11 +7,24 @@ import (
 )
 
 type ProductCategory struct {
-	ID           uuid.UUID `sql:"id" json:"id"`
-	Name         string    `sql:"name" json:"name"`
-	Price        int       `sql:"price" json:"price"`
-	Stock        int       `sql:"stock" json:"stock"`
-	CategoryName string    `sql:"category_name" json:"category_name"`
-	CreatedAt    time.Time `sql:"created_at" json:"created_at"`
-	UpdatedAt    time.Time `sql:"updated_at" json:"updated_at"`
+	ID              uuid.UUID       `sql:"id" json:"id"`
+	Name            string          `sql:"name" json:"name"`
+	SKU             *string         `sql:"sku" json:"sku"`
+	Barcodes        []string        `json:"barcodes,omitempty"`
+	Price           int             `sql:"price" json:"price"`
+	Cost            int64           `sql:"cost" json:"cost"`
+	Stock           int             `sql:"stock" json:"stock"`
+	ReorderPoint    *int            `sql:"reorder_point" json:"reorder_point"`
+	ReorderQuantity int             `sql:"reorder_quantity" json:"reorder_quantity"`
+	CategoryName    string          `sql:"category_name" json:"category_name"`
+	CreatedAt       time.Time       `sql:"created_at" json:"created_at"`
+	UpdatedAt       time.Time       `sql:"updated_at" json:"updated_at"`
+	DeletedAt       *time.Time      `sql:"deleted_at" json:"deleted_at"`
+	ParentID        *uuid.UUID      `sql:"parent_id" json:"parent_id,omitempty"`
+	HasVariants     bool            `sql:"has_variants" json:"has_variants"`
+	Options         []VariantOption `json:"options,omitempty"`
+	// Variants hanya diisi untuk produk induk
+	Variants []*ProductCategory `json:"variants,omitempty"`
+	// ModifierGroups adalah modifier yang bisa dipilih saat checkout, hanya diisi di detail produk
+	ModifierGroups []*ModifierGroup `json:"modifier_groups,omitempty"`
 }
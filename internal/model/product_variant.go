@@ -0,0 +1,8 @@
+package model
+
+// VariantOption adalah satu pilihan varian, misalnya ukuran L atau warna merah.
+// Semua varian dalam satu produk induk memakai nama opsi yang sama dengan urutan yang sama
+type VariantOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	PromotionTypeItemPercentage = "item_percentage"
+	PromotionTypeItemNominal    = "item_nominal"
+	PromotionTypeBuyXGetY       = "buy_x_get_y"
+	PromotionTypeBundlePrice    = "bundle_price"
+	PromotionTypeCartPercentage = "cart_percentage"
+	PromotionTypeCartNominal    = "cart_nominal"
+)
+
+var PromotionTypes = []string{
+	PromotionTypeItemPercentage,
+	PromotionTypeItemNominal,
+	PromotionTypeBuyXGetY,
+	PromotionTypeBundlePrice,
+	PromotionTypeCartPercentage,
+	PromotionTypeCartNominal,
+}
+
+type Promotion struct {
+	ID   uuid.UUID `sql:"id" json:"id"`
+	Name string    `sql:"name" json:"name"`
+	Type string    `sql:"type" json:"type"`
+	// Code wajib untuk promo level keranjang, kosong untuk promo level item
+	Code      string     `sql:"code" json:"code,omitempty"`
+	ProductID *uuid.UUID `sql:"product_id" json:"product_id,omitempty"`
+	// Value berupa persen (0-100) untuk tipe *_percentage, atau nominal rupiah untuk *_nominal
+	Value          int64     `sql:"value" json:"value"`
+	BuyQuantity    int       `sql:"buy_quantity" json:"buy_quantity,omitempty"`
+	GetQuantity    int       `sql:"get_quantity" json:"get_quantity,omitempty"`
+	BundleQuantity int       `sql:"bundle_quantity" json:"bundle_quantity,omitempty"`
+	BundlePrice    int64     `sql:"bundle_price" json:"bundle_price,omitempty"`
+	MinPurchase    int64     `sql:"min_purchase" json:"min_purchase,omitempty"`
+	MaxDiscount    int64     `sql:"max_discount" json:"max_discount,omitempty"`
+	StartAt        time.Time `sql:"start_at" json:"start_at"`
+	EndAt          time.Time `sql:"end_at" json:"end_at"`
+	IsActive       bool      `sql:"is_active" json:"is_active"`
+	CreatedAt      time.Time `sql:"created_at" json:"created_at"`
+	UpdatedAt      time.Time `sql:"updated_at" json:"updated_at"`
+}
+
+func (p *Promotion) IsCartLevel() bool {
+	return p.Type == PromotionTypeCartPercentage || p.Type == PromotionTypeCartNominal
+}
+
+func (p *Promotion) IsValidAt(t time.Time) bool {
+	return p.IsActive && !t.Before(p.StartAt) && t.Before(p.EndAt)
+}
+
+// ItemDiscount menghitung potongan untuk satu baris item (harga satuan x qty)
+func (p *Promotion) ItemDiscount(unitPrice int, quantity int) int64 {
+	gross := int64(unitPrice) * int64(quantity)
+	var discount int64
+
+	switch p.Type {
+	case PromotionTypeItemPercentage:
+		discount = gross * p.Value / 100
+	case PromotionTypeItemNominal:
+		discount = p.Value * int64(quantity)
+	case PromotionTypeBuyXGetY:
+		if p.BuyQuantity > 0 && p.GetQuantity > 0 {
+			free := quantity / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+			discount = int64(unitPrice) * int64(free)
+		}
+	case PromotionTypeBundlePrice:
+		if p.BundleQuantity > 0 {
+			bundles := int64(quantity / p.BundleQuantity)
+			discount = bundles * (int64(unitPrice)*int64(p.BundleQuantity) - p.BundlePrice)
+		}
+	}
+
+	return clampDiscount(discount, gross, p.MaxDiscount)
+}
+
+// CartDiscount menghitung potongan keranjang dari subtotal setelah diskon item
+func (p *Promotion) CartDiscount(subtotal int64) int64 {
+	if subtotal < p.MinPurchase {
+		return 0
+	}
+
+	var discount int64
+	switch p.Type {
+	case PromotionTypeCartPercentage:
+		discount = subtotal * p.Value / 100
+	case PromotionTypeCartNominal:
+		discount = p.Value
+	}
+
+	return clampDiscount(discount, subtotal, p.MaxDiscount)
+}
+
+func clampDiscount(discount, base, maxDiscount int64) int64 {
+	if maxDiscount > 0 && discount > maxDiscount {
+		discount = maxDiscount
+	}
+	if discount > base {
+		discount = base
+	}
+	if discount < 0 {
+		discount = 0
+	}
+
+	return discount
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusSent              = "sent"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusClosed            = "closed"
+)
+
+// PurchaseOrder: draft masih bisa diubah, sent dan partially_received bisa diterima,
+// closed berarti semua barang sudah diterima atau PO ditutup manual
+type PurchaseOrder struct {
+	ID           uuid.UUID           `sql:"id" json:"id"`
+	SupplierID   uuid.UUID           `sql:"supplier_id" json:"supplier_id"`
+	SupplierName string              `sql:"supplier_name,omitempty" json:"supplier_name,omitempty"`
+	Status       string              `sql:"status" json:"status"`
+	Note         string              `sql:"note" json:"note,omitempty"`
+	TotalCost    int64               `sql:"total_cost" json:"total_cost"`
+	CreatedBy    *uuid.UUID          `sql:"created_by" json:"created_by,omitempty"`
+	CreatedAt    time.Time           `sql:"created_at" json:"created_at"`
+	UpdatedAt    time.Time           `sql:"updated_at" json:"updated_at"`
+	SentAt       *time.Time          `sql:"sent_at" json:"sent_at,omitempty"`
+	ClosedAt     *time.Time          `sql:"closed_at" json:"closed_at,omitempty"`
+	Items        []PurchaseOrderItem `json:"items,omitempty"`
+	Receipts     []GoodsReceipt      `json:"receipts,omitempty"`
+}
+
+type PurchaseOrderItem struct {
+	ID               uuid.UUID `sql:"id" json:"id"`
+	ProductID        uuid.UUID `sql:"product_id" json:"product_id"`
+	ProductName      string    `sql:"product_name,omitempty" json:"product_name,omitempty"`
+	Quantity         int       `sql:"quantity" json:"quantity"`
+	ReceivedQuantity int       `sql:"received_quantity" json:"received_quantity"`
+	UnitCost         int64     `sql:"unit_cost" json:"unit_cost"`
+	Subtotal         int64     `json:"subtotal"`
+}
+
+func (i PurchaseOrderItem) Remaining() int {
+	return i.Quantity - i.ReceivedQuantity
+}
+
+// GoodsReceipt adalah satu kali penerimaan barang untuk sebuah PO
+type GoodsReceipt struct {
+	ID              uuid.UUID          `sql:"id" json:"id"`
+	PurchaseOrderID uuid.UUID          `sql:"purchase_order_id" json:"purchase_order_id"`
+	Note            string             `sql:"note" json:"note,omitempty"`
+	ReceivedBy      *uuid.UUID         `sql:"received_by" json:"received_by,omitempty"`
+	ReceivedByName  string             `sql:"received_by_name,omitempty" json:"received_by_name,omitempty"`
+	CreatedAt       time.Time          `sql:"created_at" json:"created_at"`
+	Items           []GoodsReceiptItem `json:"items"`
+}
+
+type GoodsReceiptItem struct {
+	ProductID   uuid.UUID `sql:"product_id" json:"product_id"`
+	ProductName string    `sql:"product_name,omitempty" json:"product_name,omitempty"`
+	Quantity    int       `sql:"quantity" json:"quantity"`
+	UnitCost    int64     `sql:"unit_cost" json:"unit_cost"`
+}
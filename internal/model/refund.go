@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	RefundTypeVoid    = "void"
+	RefundTypePartial = "partial"
+)
+
+// Refund dicatat di shift kasir yang mengembalikan uang (ShiftID), bukan shift transaksi asalnya
+type Refund struct {
+	ID            uuid.UUID      `sql:"id" json:"id"`
+	TransactionID uuid.UUID      `sql:"transaction_id" json:"transaction_id"`
+	Type          string         `sql:"type" json:"type"`
+	Reason        string         `sql:"reason" json:"reason"`
+	TotalAmount   int64          `sql:"total_amount" json:"total_amount"`
+	ShiftID       *uuid.UUID     `sql:"shift_id" json:"shift_id,omitempty"`
+	CashierID     *uuid.UUID     `sql:"cashier_id" json:"cashier_id,omitempty"`
+	CreatedAt     time.Time      `sql:"created_at" json:"created_at"`
+	Details       []RefundDetail `json:"details"`
+}
+
+// RefundDetail: Subtotal adalah bagian subtotal baris (sebelum service charge dan PPN) untuk
+// laporan revenue, Amount adalah uang yang dikembalikan ke pelanggan
+type RefundDetail struct {
+	ID                  uuid.UUID `sql:"id" json:"id"`
+	RefundID            uuid.UUID `sql:"refund_id" json:"refund_id"`
+	TransactionDetailID uuid.UUID `sql:"transaction_detail_id" json:"transaction_detail_id"`
+	ProductID           uuid.UUID `sql:"product_id" json:"product_id"`
+	Quantity            int       `sql:"quantity" json:"quantity"`
+	Subtotal            int64     `sql:"subtotal" json:"subtotal"`
+	Amount              int64     `sql:"amount" json:"amount"`
+	CreatedAt           time.Time `sql:"created_at" json:"created_at"`
+}
@@ -13,8 +13,102 @@ type TopProduct struct {
 	QuantitySold int64  `json:"qty_terjual"`
 }
 
+type PaymentMethodReport struct {
+	Method           string `json:"metode"`
+	TotalAmount      int64  `json:"total"`
+	TotalTransaction int64  `json:"total_transaksi"`
+}
+
+type TaxReport struct {
+	Subtotal      int64 `json:"subtotal"`
+	ServiceCharge int64 `json:"service_charge"`
+	TaxBase       int64 `json:"dpp"`
+	TaxAmount     int64 `json:"ppn"`
+	TotalAmount   int64 `json:"total"`
+}
+
+// CashierReport dipakai untuk evaluasi kinerja dan investigasi selisih per kasir
+type CashierReport struct {
+	CashierID        string `json:"kasir_id"`
+	Username         string `json:"username"`
+	Name             string `json:"nama"`
+	TotalTransaction int64  `json:"total_transaksi"`
+	TotalRevenue     int64  `json:"total_revenue"`
+	TotalRefund      int64  `json:"total_refund"`
+	VoidCount        int64  `json:"jumlah_void"`
+	TotalVoid        int64  `json:"total_void"`
+}
+
 type TopProductReport struct {
 	RevenueReport
 	TransactionReport
-	Products TopProduct `json:"produk_terlaris"`
+	Products       TopProduct            `json:"produk_terlaris"`
+	PaymentMethods []PaymentMethodReport `json:"metode_pembayaran"`
+	Tax            TaxReport             `json:"ringkasan_pajak"`
+	Cashiers       []CashierReport       `json:"per_kasir,omitempty"`
+}
+
+// MarginItem adalah laba kotor satu produk, kategori atau periode. Nama berisi tanggal
+// (YYYY-MM-DD atau YYYY-MM) jika dikelompokkan per periode
+type MarginItem struct {
+	ID           string  `json:"id,omitempty"`
+	Name         string  `json:"nama"`
+	QuantitySold int64   `json:"qty_terjual"`
+	Revenue      int64   `json:"revenue"`
+	Cost         int64   `json:"hpp"`
+	GrossProfit  int64   `json:"laba_kotor"`
+	Margin       float64 `json:"margin_persen"`
+}
+
+// MarginReport: revenue sama dengan total_revenue pada report utama (net setelah refund),
+// HPP memakai unit_cost yang dicatat saat transaksi
+type MarginReport struct {
+	GroupBy     string       `json:"group_by"`
+	Revenue     int64        `json:"total_revenue"`
+	Cost        int64        `json:"total_hpp"`
+	GrossProfit int64        `json:"laba_kotor"`
+	Margin      float64      `json:"margin_persen"`
+	Items       []MarginItem `json:"rincian"`
+}
+
+// ModifierReportItem: qty adalah jumlah item terjual (net setelah refund) yang memakai modifier,
+// revenue adalah tambahan harga modifier sebelum diskon
+type ModifierReportItem struct {
+	ID           string `json:"id"`
+	GroupName    string `json:"grup"`
+	Name         string `json:"nama"`
+	QuantitySold int64  `json:"qty_terjual"`
+	Revenue      int64  `json:"revenue"`
+}
+
+const (
+	ShiftReportX = "X"
+	ShiftReportZ = "Z"
+)
+
+// ShiftCashReport adalah rekonsiliasi laci kas: modal awal + penjualan tunai + kas masuk - kas keluar
+type ShiftCashReport struct {
+	OpeningFloat int64  `json:"modal_awal"`
+	CashSales    int64  `json:"penjualan_tunai"`
+	CashRefund   int64  `json:"refund_tunai"`
+	PayIn        int64  `json:"kas_masuk"`
+	PayOut       int64  `json:"kas_keluar"`
+	ExpectedCash int64  `json:"kas_seharusnya"`
+	CountedCash  *int64 `json:"kas_dihitung,omitempty"`
+	Variance     *int64 `json:"selisih,omitempty"`
+}
+
+// ShiftReport adalah laporan X (shift masih buka, sementara) atau Z (shift sudah ditutup, final).
+// TotalSales sudah dikurangi void dan refund yang diproses di shift ini
+type ShiftReport struct {
+	Type             string                `json:"jenis"`
+	Shift            Shift                 `json:"shift"`
+	TotalTransaction int64                 `json:"total_transaksi"`
+	TotalSales       int64                 `json:"total_penjualan"`
+	VoidCount        int64                 `json:"jumlah_void"`
+	TotalVoid        int64                 `json:"total_void"`
+	TotalRefund      int64                 `json:"total_refund"`
+	PaymentMethods   []PaymentMethodReport `json:"metode_pembayaran"`
+	Cash             ShiftCashReport       `json:"kas"`
+	CashMovements    []CashMovement        `json:"mutasi_kas"`
 }
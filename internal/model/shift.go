@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	ShiftStatusOpen   = "open"
+	ShiftStatusClosed = "closed"
+)
+
+const (
+	CashMovementPayIn  = "pay_in"
+	CashMovementPayOut = "pay_out"
+)
+
+// Shift mencatat laci kas satu kasir dari buka sampai tutup. ExpectedCash, CountedCash
+// dan Variance baru terisi saat shift ditutup
+type Shift struct {
+	ID           uuid.UUID  `sql:"id" json:"id"`
+	CashierID    uuid.UUID  `sql:"cashier_id" json:"cashier_id"`
+	CashierName  string     `sql:"cashier_name,omitempty" json:"cashier_name,omitempty"`
+	TerminalID   string     `sql:"terminal_id" json:"terminal_id,omitempty"`
+	Status       string     `sql:"status" json:"status"`
+	OpeningFloat int64      `sql:"opening_float" json:"opening_float"`
+	ExpectedCash *int64     `sql:"expected_cash" json:"expected_cash,omitempty"`
+	CountedCash  *int64     `sql:"counted_cash" json:"counted_cash,omitempty"`
+	Variance     *int64     `sql:"variance" json:"variance,omitempty"`
+	OpeningNote  string     `sql:"opening_note" json:"opening_note,omitempty"`
+	ClosingNote  string     `sql:"closing_note" json:"closing_note,omitempty"`
+	OpenedAt     time.Time  `sql:"opened_at" json:"opened_at"`
+	ClosedAt     *time.Time `sql:"closed_at" json:"closed_at,omitempty"`
+}
+
+// CashMovement adalah uang masuk/keluar laci di luar penjualan, contoh tambah receh atau bayar kurir
+type CashMovement struct {
+	ID        uuid.UUID  `sql:"id" json:"id"`
+	ShiftID   uuid.UUID  `sql:"shift_id" json:"shift_id"`
+	Type      string     `sql:"type" json:"type"`
+	Amount    int64      `sql:"amount" json:"amount"`
+	Reason    string     `sql:"reason" json:"reason"`
+	CreatedBy *uuid.UUID `sql:"created_by" json:"created_by,omitempty"`
+	CreatedAt time.Time  `sql:"created_at" json:"created_at"`
+}
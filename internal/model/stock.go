@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	StockMovementSale       = "sale"
+	StockMovementRefund     = "refund"
+	StockMovementReceive    = "receive"
+	StockMovementAdjustment = "adjustment"
+	StockMovementOpname     = "opname"
+)
+
+var StockMovementTypes = []string{
+	StockMovementSale,
+	StockMovementRefund,
+	StockMovementReceive,
+	StockMovementAdjustment,
+	StockMovementOpname,
+}
+
+// StockMovement adalah catatan append-only perubahan stok. Quantity bertanda:
+// positif menambah stok, negatif mengurangi. ReferenceID menunjuk dokumen sumber
+// seperti transaksi atau refund
+type StockMovement struct {
+	ID            uuid.UUID  `sql:"id" json:"id"`
+	ProductID     uuid.UUID  `sql:"product_id" json:"product_id"`
+	Type          string     `sql:"type" json:"type"`
+	Quantity      int        `sql:"quantity" json:"quantity"`
+	StockAfter    int        `sql:"stock_after" json:"stock_after"`
+	Reason        string     `sql:"reason" json:"reason,omitempty"`
+	ReferenceID   *uuid.UUID `sql:"reference_id" json:"reference_id,omitempty"`
+	CreatedBy     *uuid.UUID `sql:"created_by" json:"created_by,omitempty"`
+	CreatedByName string     `sql:"created_by_name,omitempty" json:"created_by_name,omitempty"`
+	CreatedAt     time.Time  `sql:"created_at" json:"created_at"`
+}
+
+// LowStockProduct adalah produk yang stoknya sudah di bawah atau sama dengan reorder point
+type LowStockProduct struct {
+	ID              uuid.UUID `sql:"id" json:"id"`
+	Name            string    `sql:"name" json:"name"`
+	CategoryName    string    `sql:"category_name" json:"category_name"`
+	Stock           int       `sql:"stock" json:"stock"`
+	ReorderPoint    int       `sql:"reorder_point" json:"reorder_point"`
+	ReorderQuantity int       `sql:"reorder_quantity" json:"reorder_quantity"`
+}
+
+// LowStockAlert dikirim saat sebuah movement membuat stok turun melewati reorder point
+type LowStockAlert struct {
+	ProductID       uuid.UUID  `json:"product_id"`
+	ProductName     string     `json:"product_name"`
+	Stock           int        `json:"stock"`
+	ReorderPoint    int        `json:"reorder_point"`
+	ReorderQuantity int        `json:"reorder_quantity"`
+	ReferenceID     *uuid.UUID `json:"reference_id,omitempty"`
+	OccurredAt      time.Time  `json:"occurred_at"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	StockOpnameStatusOpen      = "open"
+	StockOpnameStatusPosted    = "posted"
+	StockOpnameStatusCancelled = "cancelled"
+)
+
+// StockOpname adalah sesi hitung fisik untuk semua produk atau satu kategori.
+// Selama sesi open, item bisa dihitung berkali-kali oleh beberapa orang
+type StockOpname struct {
+	ID           uuid.UUID         `sql:"id" json:"id"`
+	CategoryID   *uuid.UUID        `sql:"category_id" json:"category_id,omitempty"`
+	CategoryName string            `sql:"category_name,omitempty" json:"category_name,omitempty"`
+	Status       string            `sql:"status" json:"status"`
+	Note         string            `sql:"note" json:"note,omitempty"`
+	CreatedBy    *uuid.UUID        `sql:"created_by" json:"created_by,omitempty"`
+	CreatedAt    time.Time         `sql:"created_at" json:"created_at"`
+	ClosedBy     *uuid.UUID        `sql:"closed_by" json:"closed_by,omitempty"`
+	ClosedAt     *time.Time        `sql:"closed_at" json:"closed_at,omitempty"`
+	ItemCount    int               `json:"item_count"`
+	CountedCount int               `json:"counted_count"`
+	Summary      *StockOpnameTotal `json:"summary,omitempty"`
+	Items        []StockOpnameItem `json:"items,omitempty"`
+}
+
+// StockOpnameItem: Variance = CountedQuantity - SystemStock, SystemStock diambil saat pertama
+// kali dihitung. CountedQuantity adalah jumlah hitungan semua penghitung di Counts, CountedBy
+// dan CountedAt penghitung terakhir. Penjualan setelah dihitung terlihat dari selisih
+// SystemStock dengan CurrentStock
+type StockOpnameItem struct {
+	ProductID       uuid.UUID          `sql:"product_id" json:"product_id"`
+	ProductName     string             `sql:"product_name,omitempty" json:"product_name"`
+	Price           int                `sql:"price,omitempty" json:"price"`
+	StartStock      int                `sql:"start_stock" json:"start_stock"`
+	SystemStock     *int               `sql:"system_stock" json:"system_stock,omitempty"`
+	CountedQuantity *int               `sql:"counted_quantity" json:"counted_quantity,omitempty"`
+	Variance        *int               `json:"variance,omitempty"`
+	CurrentStock    int                `sql:"current_stock,omitempty" json:"current_stock"`
+	CountedBy       *uuid.UUID         `sql:"counted_by" json:"counted_by,omitempty"`
+	CountedByName   string             `sql:"counted_by_name,omitempty" json:"counted_by_name,omitempty"`
+	CountedAt       *time.Time         `sql:"counted_at" json:"counted_at,omitempty"`
+	Adjustment      *int               `sql:"adjustment" json:"adjustment,omitempty"`
+	Counts          []StockOpnameCount `json:"counts,omitempty"`
+}
+
+// StockOpnameCount adalah hasil hitung satu penghitung untuk satu produk
+type StockOpnameCount struct {
+	CountedBy       uuid.UUID `sql:"counted_by" json:"counted_by"`
+	CountedByName   string    `sql:"counted_by_name,omitempty" json:"counted_by_name,omitempty"`
+	CountedQuantity int       `sql:"counted_quantity" json:"counted_quantity"`
+	CountedAt       time.Time `sql:"counted_at" json:"counted_at"`
+}
+
+// StockOpnameTotal merangkum selisih item yang sudah dihitung, nilai memakai harga jual
+type StockOpnameTotal struct {
+	SurplusQuantity  int   `json:"surplus_quantity"`
+	ShortageQuantity int   `json:"shortage_quantity"`
+	VarianceValue    int64 `json:"variance_value"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type Supplier struct {
+	ID          uuid.UUID `sql:"id" json:"id"`
+	Name        string    `sql:"name" json:"name"`
+	ContactName string    `sql:"contact_name" json:"contact_name,omitempty"`
+	Phone       string    `sql:"phone" json:"phone,omitempty"`
+	Email       string    `sql:"email" json:"email,omitempty"`
+	Address     string    `sql:"address" json:"address,omitempty"`
+	CreatedAt   time.Time `sql:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `sql:"updated_at" json:"updated_at"`
+}
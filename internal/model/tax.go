@@ -0,0 +1,50 @@
+package model
+
+import "math"
+
+// TaxConfig berisi tarif PPN dan service charge dalam persen
+type TaxConfig struct {
+	Rate              float64
+	Inclusive         bool
+	ServiceChargeRate float64
+}
+
+type TaxSummary struct {
+	Subtotal      int64
+	ServiceCharge int64
+	TaxBase       int64
+	TaxAmount     int64
+	TotalAmount   int64
+}
+
+// Calculate menghitung service charge dan PPN dari subtotal (setelah diskon).
+// taxableSubtotal adalah bagian subtotal dari kategori yang tidak dibebaskan pajak.
+// Service charge ikut dikenai PPN secara proporsional terhadap bagian yang kena pajak.
+func (c TaxConfig) Calculate(subtotal, taxableSubtotal int64) TaxSummary {
+	summary := TaxSummary{
+		Subtotal:      subtotal,
+		ServiceCharge: percentOf(subtotal, c.ServiceChargeRate),
+	}
+
+	taxable := taxableSubtotal
+	if subtotal > 0 {
+		taxable += summary.ServiceCharge * taxableSubtotal / subtotal
+	}
+
+	if c.Inclusive {
+		// harga sudah termasuk PPN, pisahkan DPP dari harga jual
+		summary.TaxBase = int64(math.Round(float64(taxable) * 100 / (100 + c.Rate)))
+		summary.TaxAmount = taxable - summary.TaxBase
+		summary.TotalAmount = subtotal + summary.ServiceCharge
+	} else {
+		summary.TaxBase = taxable
+		summary.TaxAmount = percentOf(taxable, c.Rate)
+		summary.TotalAmount = subtotal + summary.ServiceCharge + summary.TaxAmount
+	}
+
+	return summary
+}
+
+func percentOf(amount int64, rate float64) int64 {
+	return int64(math.Round(float64(amount) * rate / 100))
+}
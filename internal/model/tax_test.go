@@ -0,0 +1,93 @@
+package model
+
+import "testing"
+
+func TestTaxConfigCalculate(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          TaxConfig
+		subtotal        int64
+		taxableSubtotal int64
+		want            TaxSummary
+	}{
+		{
+			name:            "no tax",
+			config:          TaxConfig{},
+			subtotal:        20000,
+			taxableSubtotal: 20000,
+			want:            TaxSummary{Subtotal: 20000, TaxBase: 20000, TotalAmount: 20000},
+		},
+		{
+			name:            "exclusive",
+			config:          TaxConfig{Rate: 11},
+			subtotal:        20000,
+			taxableSubtotal: 20000,
+			want:            TaxSummary{Subtotal: 20000, TaxBase: 20000, TaxAmount: 2200, TotalAmount: 22200},
+		},
+		{
+			name:            "exclusive rounds half up",
+			config:          TaxConfig{Rate: 11},
+			subtotal:        1050,
+			taxableSubtotal: 1050,
+			want:            TaxSummary{Subtotal: 1050, TaxBase: 1050, TaxAmount: 116, TotalAmount: 1166},
+		},
+		{
+			name:            "inclusive",
+			config:          TaxConfig{Rate: 11, Inclusive: true},
+			subtotal:        11100,
+			taxableSubtotal: 11100,
+			want:            TaxSummary{Subtotal: 11100, TaxBase: 10000, TaxAmount: 1100, TotalAmount: 11100},
+		},
+		{
+			name:            "inclusive rounds tax base",
+			config:          TaxConfig{Rate: 11, Inclusive: true},
+			subtotal:        10000,
+			taxableSubtotal: 10000,
+			want:            TaxSummary{Subtotal: 10000, TaxBase: 9009, TaxAmount: 991, TotalAmount: 10000},
+		},
+		{
+			name:            "service charge is taxed",
+			config:          TaxConfig{Rate: 11, ServiceChargeRate: 5},
+			subtotal:        20000,
+			taxableSubtotal: 20000,
+			want:            TaxSummary{Subtotal: 20000, ServiceCharge: 1000, TaxBase: 21000, TaxAmount: 2310, TotalAmount: 23310},
+		},
+		{
+			name:            "partly exempt",
+			config:          TaxConfig{Rate: 11, ServiceChargeRate: 10},
+			subtotal:        20000,
+			taxableSubtotal: 10000,
+			want:            TaxSummary{Subtotal: 20000, ServiceCharge: 2000, TaxBase: 11000, TaxAmount: 1210, TotalAmount: 23210},
+		},
+		{
+			name:            "fully exempt",
+			config:          TaxConfig{Rate: 11},
+			subtotal:        20000,
+			taxableSubtotal: 0,
+			want:            TaxSummary{Subtotal: 20000, TotalAmount: 20000},
+		},
+		{
+			name:            "inclusive with service charge",
+			config:          TaxConfig{Rate: 11, Inclusive: true, ServiceChargeRate: 10},
+			subtotal:        11100,
+			taxableSubtotal: 11100,
+			want:            TaxSummary{Subtotal: 11100, ServiceCharge: 1110, TaxBase: 11000, TaxAmount: 1210, TotalAmount: 12210},
+		},
+		{
+			name:            "empty cart",
+			config:          TaxConfig{Rate: 11, ServiceChargeRate: 10},
+			subtotal:        0,
+			taxableSubtotal: 0,
+			want:            TaxSummary{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.config.Calculate(tt.subtotal, tt.taxableSubtotal)
+			if got != tt.want {
+				t.Errorf("Calculate(%d, %d) = %+v, want %+v", tt.subtotal, tt.taxableSubtotal, got, tt.want)
+			}
+		})
+	}
+}
@@ -6,19 +6,54 @@ import (
 	"github.com/gofrs/uuid/v5"
 )
 
+const (
+	TransactionStatusCompleted         = "completed"
+	TransactionStatusPartiallyRefunded = "partially_refunded"
+	TransactionStatusRefunded          = "refunded"
+	TransactionStatusVoided            = "voided"
+)
+
 type Transaction struct {
-	ID          uuid.UUID           `sql:"id" json:"id"`
-	TotalAmount int64               `sql:"total_amount" json:"total_amount"`
-	CreatedAt   time.Time           `sql:"created_at" json:"created_at"`
-	Details     []TransactionDetail `json:"details"`
+	ID             uuid.UUID            `sql:"id" json:"id"`
+	Subtotal       int64                `sql:"subtotal" json:"subtotal"`
+	ServiceCharge  int64                `sql:"service_charge" json:"service_charge"`
+	TaxBase        int64                `sql:"tax_base" json:"tax_base"`
+	TaxAmount      int64                `sql:"tax_amount" json:"tax_amount"`
+	TaxInclusive   bool                 `sql:"tax_inclusive" json:"tax_inclusive"`
+	TotalAmount    int64                `sql:"total_amount" json:"total_amount"`
+	DiscountAmount int64                `sql:"discount_amount" json:"discount_amount"`
+	PromotionID    *uuid.UUID           `sql:"promotion_id" json:"promotion_id,omitempty"`
+	PromoCode      string               `sql:"promo_code" json:"promo_code,omitempty"`
+	PaidAmount     int64                `sql:"paid_amount" json:"paid_amount"`
+	ChangeAmount   int64                `sql:"change_amount" json:"change_amount"`
+	CashierID      *uuid.UUID           `sql:"cashier_id" json:"cashier_id,omitempty"`
+	CashierName    string               `sql:"cashier_name,omitempty" json:"cashier_name,omitempty"`
+	TerminalID     string               `sql:"terminal_id" json:"terminal_id,omitempty"`
+	ShiftID        *uuid.UUID           `sql:"shift_id" json:"shift_id,omitempty"`
+	Status         string               `sql:"status" json:"status"`
+	CreatedAt      time.Time            `sql:"created_at" json:"created_at"`
+	Details        []TransactionDetail  `json:"details"`
+	Payments       []TransactionPayment `json:"payments"`
 }
 
 type TransactionDetail struct {
 	ID            uuid.UUID `sql:"id" json:"id"`
 	TransactionID uuid.UUID `sql:"transaction_id" json:"transaction_id"`
 	ProductID     uuid.UUID `sql:"product_id" json:"product_id"`
-	ProductName   string    `sql:"product_name,omitempty" json:"product_name,omitempty"`
-	Quantity      int       `sql:"quantity" json:"quantity"`
-	Subtotal      int64     `sql:"subtotal" json:"subtotal"`
-	CreatedAt     time.Time `sql:"created_at" json:"created_at"`
+	// ProductName, SKU, UnitPrice dan kategori adalah snapshot produk saat transaksi.
+	// UnitPrice sudah termasuk tambahan harga modifier
+	ProductName  string     `sql:"product_name" json:"product_name"`
+	SKU          *string    `sql:"sku" json:"sku,omitempty"`
+	UnitPrice    int64      `sql:"unit_price" json:"unit_price"`
+	CategoryID   *uuid.UUID `sql:"category_id" json:"category_id,omitempty"`
+	CategoryName string     `sql:"category_name" json:"category_name"`
+	Quantity     int        `sql:"quantity" json:"quantity"`
+	// UnitCost adalah HPP per unit saat transaksi, dipakai untuk laporan laba kotor
+	UnitCost    int64      `sql:"unit_cost" json:"unit_cost"`
+	Discount    int64      `sql:"discount" json:"discount"`
+	PromotionID *uuid.UUID `sql:"promotion_id" json:"promotion_id,omitempty"`
+	Subtotal    int64      `sql:"subtotal" json:"subtotal"`
+	CreatedAt   time.Time  `sql:"created_at" json:"created_at"`
+	// Modifiers adalah pilihan tambahan item, dipakai juga untuk tiket dapur
+	Modifiers []TransactionDetailModifier `json:"modifiers,omitempty"`
 }
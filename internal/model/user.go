@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	RoleCashier = "cashier"
+	RoleManager = "manager"
+	RoleAdmin   = "admin"
+)
+
+var Roles = []string{
+	RoleCashier,
+	RoleManager,
+	RoleAdmin,
+}
+
+// roleRank dipakai untuk hierarki role: admin boleh semua yang boleh manager,
+// manager boleh semua yang boleh cashier
+var roleRank = map[string]int{
+	RoleCashier: 1,
+	RoleManager: 2,
+	RoleAdmin:   3,
+}
+
+// RoleAllows mengecek apakah role punya akses minimal setara required
+func RoleAllows(role, required string) bool {
+	rank, ok := roleRank[role]
+	return ok && rank >= roleRank[required]
+}
+
+type User struct {
+	ID           uuid.UUID `sql:"id" json:"id"`
+	Username     string    `sql:"username" json:"username"`
+	Name         string    `sql:"name" json:"name"`
+	PasswordHash string    `sql:"password_hash" json:"-"`
+	Role         string    `sql:"role" json:"role"`
+	IsActive     bool      `sql:"is_active" json:"is_active"`
+	CreatedAt    time.Time `sql:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `sql:"updated_at" json:"updated_at"`
+}
+
+// RefreshToken hanya menyimpan hash token, token aslinya cuma dikirim sekali ke client
+type RefreshToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+type AuthToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	User         *User  `json:"user"`
+}
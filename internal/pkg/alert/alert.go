@@ -0,0 +1,74 @@
+package alert
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/bytedance/sonic"
+)
+
+// Notifier meneruskan alert stok menipis ke bagian purchasing
+type Notifier interface {
+	LowStock(alerts []model.LowStockAlert)
+}
+
+type notifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewNotifier selalu menulis alert ke log. Jika webhookURL diisi, alert juga dikirim
+// sebagai POST JSON di background supaya checkout tidak menunggu webhook
+func NewNotifier(webhookURL string) Notifier {
+	return &notifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (n *notifier) LowStock(alerts []model.LowStockAlert) {
+	if len(alerts) == 0 {
+		return
+	}
+
+	for _, a := range alerts {
+		log.Printf("low stock: product %s (%s) stock %d <= reorder point %d, reorder %d",
+			a.ProductID, a.ProductName, a.Stock, a.ReorderPoint, a.ReorderQuantity)
+	}
+
+	if n.webhookURL == "" {
+		return
+	}
+
+	go func() {
+		if err := n.send(alerts); err != nil {
+			log.Printf("low stock webhook failed: %v", err)
+		}
+	}()
+}
+
+func (n *notifier) send(alerts []model.LowStockAlert) error {
+	payload, err := sonic.Marshal(map[string]any{
+		"event":  "stock.low",
+		"alerts": alerts,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := n.client.Post(n.webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package apperror
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type Kind string
+
+const (
+	KindBadRequest   Kind = "bad_request"
+	KindUnauthorized Kind = "unauthorized"
+	KindForbidden    Kind = "forbidden"
+	KindNotFound     Kind = "not_found"
+	KindConflict     Kind = "conflict"
+	KindValidation   Kind = "validation"
+	KindInternal     Kind = "internal"
+)
+
+// Coded adalah error domain yang punya kategori (Kind) dan kode yang bisa dibaca mesin
+type Coded interface {
+	error
+	Kind() Kind
+	Code() string
+}
+
+// Detailed adalah error domain yang membawa data tambahan untuk client
+type Detailed interface {
+	Details() any
+}
+
+// Fielded adalah error validasi yang membawa pesan per field
+type Fielded interface {
+	FieldErrors() map[string]string
+}
+
+type Error struct {
+	kind    Kind
+	code    string
+	message string
+	cause   error
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{
+		kind:    kind,
+		code:    code,
+		message: message,
+	}
+}
+
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+func Validation(code, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+func Unauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// BadRequest membungkus error dari parsing request (JSON, query param, dll)
+func BadRequest(code string, cause error) *Error {
+	return &Error{
+		kind:    KindBadRequest,
+		code:    code,
+		message: cause.Error(),
+		cause:   cause,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+func (e *Error) Kind() Kind {
+	return e.kind
+}
+
+func (e *Error) Code() string {
+	return e.code
+}
+
+type InsufficientStockError struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Available int       `json:"available"`
+	Requested int       `json:"requested"`
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for %s: have %d, need %d", e.ProductID, e.Available, e.Requested)
+}
+
+func (e *InsufficientStockError) Kind() Kind {
+	return KindConflict
+}
+
+func (e *InsufficientStockError) Code() string {
+	return "INSUFFICIENT_STOCK"
+}
+
+func (e *InsufficientStockError) Details() any {
+	return e
+}
+
+// KindOf mencari Kind dari rantai error, sql.ErrNoRows dianggap not found
+func KindOf(err error) Kind {
+	var coded Coded
+	if errors.As(err, &coded) {
+		return coded.Kind()
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return KindNotFound
+	}
+
+	return KindInternal
+}
+
+func CodeOf(err error) string {
+	var coded Coded
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return "NOT_FOUND"
+	}
+
+	return "INTERNAL_ERROR"
+}
+
+func DetailsOf(err error) any {
+	var detailed Detailed
+	if errors.As(err, &detailed) {
+		return detailed.Details()
+	}
+
+	return nil
+}
+
+func FieldErrorsOf(err error) map[string]string {
+	var fielded Fielded
+	if errors.As(err, &fielded) {
+		return fielded.FieldErrors()
+	}
+
+	return nil
+}
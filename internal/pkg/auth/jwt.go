@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/bytedance/sonic"
+)
+
+// header JWT selalu sama karena hanya HS256 yang didukung
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type Claims struct {
+	Subject   string `json:"sub"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type JWT struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewJWT(secret string, ttl time.Duration) *JWT {
+	return &JWT{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+func (j *JWT) TTL() time.Duration {
+	return j.ttl
+}
+
+// Sign membuat access token HS256, iat dan exp diisi dari waktu sekarang
+func (j *JWT) Sign(claims Claims) (string, error) {
+	now := time.Now()
+	claims.IssuedAt = now.Unix()
+	claims.ExpiresAt = now.Add(j.ttl).Unix()
+
+	payload, err := sonic.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + j.signature(unsigned), nil
+}
+
+// Parse memverifikasi signature dan masa berlaku token
+func (j *JWT) Parse(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return nil, utils.ErrUnauthenticated
+	}
+
+	expected := j.signature(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return nil, utils.ErrUnauthenticated
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, utils.ErrUnauthenticated
+	}
+
+	var claims Claims
+	if err := sonic.Unmarshal(payload, &claims); err != nil {
+		return nil, utils.ErrUnauthenticated
+	}
+
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, utils.ErrTokenExpired
+	}
+
+	return &claims, nil
+}
+
+func (j *JWT) signature(unsigned string) string {
+	mac := hmac.New(sha256.New, j.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// NewRefreshToken membuat token acak untuk client beserta hash yang disimpan di database
+func NewRefreshToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashToken(token), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
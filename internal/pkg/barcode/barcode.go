@@ -0,0 +1,191 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	FormatEAN13   = "ean13"
+	FormatEAN8    = "ean8"
+	FormatCode128 = "code128"
+
+	// QuietZone adalah jumlah modul kosong di kiri dan kanan barcode supaya mudah di-scan
+	QuietZone = 10
+)
+
+var ErrInvalidCode = errors.New("invalid barcode value")
+
+// Barcode berisi modul hasil encode, true berarti bar hitam selebar satu modul
+type Barcode struct {
+	Format  string
+	Code    string
+	Modules []bool
+}
+
+// Encode memilih EAN-13/EAN-8 untuk kode numerik yang valid (UPC-A dianggap EAN-13
+// dengan awalan 0) dan Code128 untuk kode lain seperti SKU atau GTIN-14
+func Encode(code string) (*Barcode, error) {
+	if isDigits(code) {
+		switch len(code) {
+		case 8:
+			if validCheckDigit(code) {
+				return EncodeEAN8(code)
+			}
+		case 12, 13:
+			if validCheckDigit(code) {
+				return EncodeEAN13(code)
+			}
+		}
+	}
+
+	return EncodeCode128(code)
+}
+
+// EncodeEAN13 menerima 13 digit EAN atau 12 digit UPC-A
+func EncodeEAN13(code string) (*Barcode, error) {
+	if len(code) == 12 {
+		code = "0" + code
+	}
+
+	if len(code) != 13 || !isDigits(code) || !validCheckDigit(code) {
+		return nil, fmt.Errorf("%w: %q is not an EAN-13", ErrInvalidCode, code)
+	}
+
+	modules := make([]bool, 0, 95)
+	modules = appendPattern(modules, "101")
+
+	parity := eanParity[code[0]-'0']
+	for i := 1; i <= 6; i++ {
+		digit := code[i] - '0'
+		if parity[i-1] == 'G' {
+			modules = appendPattern(modules, eanG[digit])
+		} else {
+			modules = appendPattern(modules, eanL[digit])
+		}
+	}
+
+	modules = appendPattern(modules, "01010")
+	for i := 7; i <= 12; i++ {
+		modules = appendPattern(modules, eanR[code[i]-'0'])
+	}
+	modules = appendPattern(modules, "101")
+
+	return &Barcode{Format: FormatEAN13, Code: code, Modules: modules}, nil
+}
+
+func EncodeEAN8(code string) (*Barcode, error) {
+	if len(code) != 8 || !isDigits(code) || !validCheckDigit(code) {
+		return nil, fmt.Errorf("%w: %q is not an EAN-8", ErrInvalidCode, code)
+	}
+
+	modules := make([]bool, 0, 67)
+	modules = appendPattern(modules, "101")
+	for i := 0; i < 4; i++ {
+		modules = appendPattern(modules, eanL[code[i]-'0'])
+	}
+	modules = appendPattern(modules, "01010")
+	for i := 4; i < 8; i++ {
+		modules = appendPattern(modules, eanR[code[i]-'0'])
+	}
+	modules = appendPattern(modules, "101")
+
+	return &Barcode{Format: FormatEAN8, Code: code, Modules: modules}, nil
+}
+
+// EncodeCode128 memakai code set B, cukup untuk huruf, angka dan tanda baca ASCII
+func EncodeCode128(code string) (*Barcode, error) {
+	if code == "" || len(code) > 80 {
+		return nil, fmt.Errorf("%w: code128 value must be 1-80 characters", ErrInvalidCode)
+	}
+
+	values := make([]int, 0, len(code)+3)
+	values = append(values, code128StartB)
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		if c < 32 || c > 126 {
+			return nil, fmt.Errorf("%w: %q contains unsupported character", ErrInvalidCode, code)
+		}
+		values = append(values, int(c)-32)
+	}
+
+	checksum := values[0]
+	for i, v := range values[1:] {
+		checksum += v * (i + 1)
+	}
+	values = append(values, checksum%103, code128Stop)
+
+	modules := make([]bool, 0, len(values)*11+2)
+	for _, v := range values {
+		// pola diawali bar lalu bergantian dengan spasi
+		for i, width := range code128Widths[v] {
+			for range width - '0' {
+				modules = append(modules, i%2 == 0)
+			}
+		}
+	}
+
+	return &Barcode{Format: FormatCode128, Code: code, Modules: modules}, nil
+}
+
+func appendPattern(modules []bool, pattern string) []bool {
+	for i := 0; i < len(pattern); i++ {
+		modules = append(modules, pattern[i] == '1')
+	}
+	return modules
+}
+
+func isDigits(code string) bool {
+	if code == "" {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] < '0' || code[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// validCheckDigit menghitung check digit GS1 (bobot 3 dan 1 dari kanan)
+func validCheckDigit(code string) bool {
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := int(code[i] - '0')
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
+
+var (
+	eanL = [10]string{"0001101", "0011001", "0010011", "0111101", "0100011", "0110001", "0101111", "0111011", "0110111", "0001011"}
+	eanG = [10]string{"0100111", "0110011", "0011011", "0100001", "0011101", "0111001", "0000101", "0010001", "0001001", "0010111"}
+	eanR = [10]string{"1110010", "1100110", "1101100", "1000010", "1011100", "1001110", "1010000", "1000100", "1001000", "1110100"}
+
+	// eanParity menentukan pola L/G enam digit kiri berdasarkan digit pertama EAN-13
+	eanParity = [10]string{"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG", "LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL"}
+)
+
+const (
+	code128StartB = 104
+	code128Stop   = 106
+)
+
+// code128Widths adalah lebar bar dan spasi bergantian untuk nilai 0-106
+var code128Widths = [107]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
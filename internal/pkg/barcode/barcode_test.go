@@ -0,0 +1,144 @@
+package barcode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// modulesString mengubah modul menjadi "1" (bar) dan "0" (spasi) supaya mudah dibandingkan
+func modulesString(modules []bool) string {
+	var b strings.Builder
+	for _, m := range modules {
+		if m {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	return b.String()
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		format  string
+		encoded string
+		modules string
+	}{
+		{
+			name:    "ean13",
+			code:    "5901234123457",
+			format:  FormatEAN13,
+			encoded: "5901234123457",
+			modules: "10100010110100111011001100100110111101001110101010110011011011001000010101110010011101000100101",
+		},
+		{
+			name:    "upc-a as ean13",
+			code:    "012345678905",
+			format:  FormatEAN13,
+			encoded: "0012345678905",
+			modules: "10100011010011001001001101111010100011011000101010101000010001001001000111010011100101001110101",
+		},
+		{
+			name:    "ean8",
+			code:    "96385074",
+			format:  FormatEAN8,
+			encoded: "96385074",
+			modules: "1010001011010111101111010110111010101001110111001010001001011100101",
+		},
+		{
+			name:    "code128 set b",
+			code:    "ABC",
+			format:  FormatCode128,
+			encoded: "ABC",
+			// start B, A, B, C, checksum 1, stop
+			modules: "11010010000" + "10100011000" + "10001011000" + "10001000110" + "11001101100" + "1100011101011",
+		},
+		{
+			name:    "wrong check digit falls back to code128",
+			code:    "5901234123458",
+			format:  FormatCode128,
+			encoded: "5901234123458",
+		},
+		{
+			name:    "gtin-14 uses code128",
+			code:    "15901234123454",
+			format:  FormatCode128,
+			encoded: "15901234123454",
+		},
+		{
+			name:    "sku uses code128",
+			code:    "KS-L",
+			format:  FormatCode128,
+			encoded: "KS-L",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Encode(tt.code)
+			if err != nil {
+				t.Fatalf("Encode(%q) error: %v", tt.code, err)
+			}
+
+			if got.Format != tt.format || got.Code != tt.encoded {
+				t.Errorf("Encode(%q) = %s %q, want %s %q", tt.code, got.Format, got.Code, tt.format, tt.encoded)
+			}
+
+			if tt.modules != "" && modulesString(got.Modules) != tt.modules {
+				t.Errorf("Encode(%q) modules\n got %s\nwant %s", tt.code, modulesString(got.Modules), tt.modules)
+			}
+		})
+	}
+}
+
+func TestEncodeLength(t *testing.T) {
+	tests := []struct {
+		code   string
+		length int
+	}{
+		{code: "5901234123457", length: 95},
+		{code: "96385074", length: 67},
+		// start, data, checksum masing-masing 11 modul, stop 13 modul
+		{code: "SKU-001", length: 11*(7+2) + 13},
+	}
+
+	for _, tt := range tests {
+		got, err := Encode(tt.code)
+		if err != nil {
+			t.Fatalf("Encode(%q) error: %v", tt.code, err)
+		}
+
+		if len(got.Modules) != tt.length {
+			t.Errorf("Encode(%q) has %d modules, want %d", tt.code, len(got.Modules), tt.length)
+		}
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		encode func(string) (*Barcode, error)
+		code   string
+	}{
+		{name: "ean13 wrong check digit", encode: EncodeEAN13, code: "5901234123458"},
+		{name: "ean13 not numeric", encode: EncodeEAN13, code: "59012341234A7"},
+		{name: "ean13 wrong length", encode: EncodeEAN13, code: "96385074"},
+		{name: "ean8 wrong check digit", encode: EncodeEAN8, code: "96385075"},
+		{name: "ean8 wrong length", encode: EncodeEAN8, code: "5901234123457"},
+		{name: "code128 empty", encode: EncodeCode128, code: ""},
+		{name: "code128 too long", encode: EncodeCode128, code: strings.Repeat("A", 81)},
+		{name: "code128 non ascii", encode: EncodeCode128, code: "kopié"},
+		{name: "code128 control character", encode: EncodeCode128, code: "A\tB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.encode(tt.code); !errors.Is(err, ErrInvalidCode) {
+				t.Errorf("encode(%q) error = %v, want ErrInvalidCode", tt.code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package barcode
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// PNG menggambar barcode hitam di atas latar putih dengan quiet zone di kiri dan kanan
+func (b *Barcode) PNG(w io.Writer, moduleWidth, height int) error {
+	width := (len(b.Modules) + QuietZone*2) * moduleWidth
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	for i, black := range b.Modules {
+		if !black {
+			continue
+		}
+
+		startX := (QuietZone + i) * moduleWidth
+		for x := startX; x < startX+moduleWidth; x++ {
+			for y := 0; y < height; y++ {
+				img.SetGray(x, y, color.Gray{Y: 0})
+			}
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// SVG menghasilkan barcode sebagai vektor, bar yang bersebelahan digabung jadi satu rect
+func (b *Barcode) SVG(moduleWidth, height int) string {
+	width := (len(b.Modules) + QuietZone*2) * moduleWidth
+
+	var svg strings.Builder
+	fmt.Fprintf(
+		&svg,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="%[2]d" viewBox="0 0 %[1]d %[2]d"><rect width="100%%" height="100%%" fill="#fff"/><g fill="#000">`,
+		width,
+		height,
+	)
+
+	for i := 0; i < len(b.Modules); {
+		if !b.Modules[i] {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(b.Modules) && b.Modules[i] {
+			i++
+		}
+		fmt.Fprintf(&svg, `<rect x="%d" width="%d" height="%d"/>`, (QuietZone+start)*moduleWidth, (i-start)*moduleWidth, height)
+	}
+
+	svg.WriteString(`</g></svg>`)
+	return svg.String()
+}
@@ -0,0 +1,52 @@
+package label
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"strconv"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/barcode"
+)
+
+// Label adalah satu label rak, Barcode boleh kosong jika produk belum punya barcode atau SKU
+type Label struct {
+	Name    string
+	Price   int
+	Barcode *barcode.Barcode
+}
+
+//go:embed sheet.html
+var sheetHTML string
+
+var sheet = template.Must(template.New("sheet").Funcs(template.FuncMap{
+	"rupiah": Rupiah,
+	"svg": func(b *barcode.Barcode) template.HTML {
+		// SVG dibuat sendiri dari modul barcode, tidak ada input user yang ikut di-render
+		return template.HTML(b.SVG(1, 40))
+	},
+}).Parse(sheetHTML))
+
+// Sheet menulis lembar label siap cetak (A4, 3 kolom) sebagai HTML
+func Sheet(w io.Writer, labels []Label) error {
+	return sheet.Execute(w, labels)
+}
+
+// Rupiah memformat harga dengan pemisah ribuan titik, contoh Rp 12.500
+func Rupiah(amount int) string {
+	digits := strconv.Itoa(amount)
+	sign := ""
+	if amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	formatted := make([]byte, 0, len(digits)+len(digits)/3)
+	for i := range len(digits) {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			formatted = append(formatted, '.')
+		}
+		formatted = append(formatted, digits[i])
+	}
+
+	return "Rp " + sign + string(formatted)
+}
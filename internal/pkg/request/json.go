@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
 	"github.com/bytedance/sonic"
 )
 
@@ -12,9 +13,12 @@ func BindJSON[T any](r *http.Request) (T, error) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		return result, err
+		return result, apperror.BadRequest("INVALID_REQUEST_BODY", err)
 	}
 
-	err = sonic.Unmarshal(body, &result)
-	return result, err
+	if err := sonic.Unmarshal(body, &result); err != nil {
+		return result, apperror.BadRequest("INVALID_JSON", err)
+	}
+
+	return result, nil
 }
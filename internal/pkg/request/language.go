@@ -0,0 +1,20 @@
+package request
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Language mengambil bahasa utama dari header Accept-Language,
+// contoh "id-ID,id;q=0.9,en;q=0.8" menjadi "id-ID"
+func Language(r *http.Request) string {
+	header := r.Header.Get("Accept-Language")
+	if header == "" {
+		return "en"
+	}
+
+	lang, _, _ := strings.Cut(header, ",")
+	lang, _, _ = strings.Cut(lang, ";")
+
+	return strings.TrimSpace(lang)
+}
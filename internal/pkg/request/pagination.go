@@ -32,7 +32,7 @@ func Paginate(page, pageSize string) *PaginateQuery {
 	}
 
 	offset := (pageInt - 1) * pageSizeInt
-	limit := pageInt
+	limit := pageSizeInt
 
 	res := &PaginateQuery{
 		Limit:  limit,
@@ -3,6 +3,7 @@ package response
 import (
 	"net/http"
 
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
 	"github.com/bytedance/sonic"
 )
 
@@ -13,11 +14,14 @@ type Meta struct {
 }
 
 type Response struct {
-	Status  string `json:"status"`
-	Message string `json:"message,omitempty"`
-	Data    any    `json:"data,omitempty"`
-	Error   string `json:"error,omitempty"`
-	Meta    *Meta  `json:"meta,omitempty"`
+	Status  string            `json:"status"`
+	Message string            `json:"message,omitempty"`
+	Data    any               `json:"data,omitempty"`
+	Error   string            `json:"error,omitempty"`
+	Code    string            `json:"code,omitempty"`
+	Details any               `json:"details,omitempty"`
+	Errors  map[string]string `json:"errors,omitempty"`
+	Meta    *Meta             `json:"meta,omitempty"`
 }
 
 func OK(m string, data any, meta *Meta) *Response {
@@ -34,6 +38,30 @@ func Failed(m string, err error) *Response {
 		Status:  "FAILED",
 		Message: m,
 		Error:   err.Error(),
+		Code:    apperror.CodeOf(err),
+		Details: apperror.DetailsOf(err),
+		Errors:  apperror.FieldErrorsOf(err),
+	}
+}
+
+// Status memetakan error domain ke HTTP status code,
+// error yang tidak dikenal dianggap internal server error
+func Status(err error) int {
+	switch apperror.KindOf(err) {
+	case apperror.KindBadRequest:
+		return http.StatusBadRequest
+	case apperror.KindUnauthorized:
+		return http.StatusUnauthorized
+	case apperror.KindForbidden:
+		return http.StatusForbidden
+	case apperror.KindNotFound:
+		return http.StatusNotFound
+	case apperror.KindConflict:
+		return http.StatusConflict
+	case apperror.KindValidation:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
 	}
 }
 
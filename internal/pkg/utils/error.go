@@ -1,7 +1,58 @@
 package utils
 
-import "errors"
+import "github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
 
 var (
-	ErrCategoryNotFound = errors.New("category not found")
-)
\ No newline at end of file
+	ErrCategoryNotFound           = apperror.NotFound("CATEGORY_NOT_FOUND", "category not found")
+	ErrProductNotFound            = apperror.NotFound("PRODUCT_NOT_FOUND", "product not found")
+	ErrCategoryInUse              = apperror.Conflict("CATEGORY_IN_USE", "category still has active products")
+	ErrCategoryArchived           = apperror.Conflict("CATEGORY_ARCHIVED", "category is archived")
+	ErrProductArchived            = apperror.Conflict("PRODUCT_ARCHIVED", "product is archived")
+	ErrBarcodeNotFound            = apperror.NotFound("BARCODE_NOT_FOUND", "barcode not found")
+	ErrProductHasVariants         = apperror.Conflict("PRODUCT_HAS_VARIANTS", "product has variants, use one of its variants instead")
+	ErrProductIsVariant           = apperror.Conflict("PRODUCT_IS_VARIANT", "product is a variant, update it through its parent product")
+	ErrInvalidVariantParent       = apperror.Conflict("INVALID_VARIANT_PARENT", "variants can only be added to an active product that is not a variant")
+	ErrInvalidVariantOptions      = apperror.Validation("INVALID_VARIANT_OPTIONS", "variant options must use the same names as the other variants")
+	ErrVariantExists              = apperror.Conflict("VARIANT_EXISTS", "variant with the same options already exists")
+	ErrProductHasNoBarcode        = apperror.Validation("PRODUCT_HAS_NO_BARCODE", "product has no barcode or SKU")
+	ErrUnencodableBarcode         = apperror.Validation("UNENCODABLE_BARCODE", "barcode value cannot be encoded")
+	ErrModifierGroupNotFound      = apperror.NotFound("MODIFIER_GROUP_NOT_FOUND", "modifier group not found")
+	ErrInvalidModifierGroup       = apperror.Validation("INVALID_MODIFIER_GROUP", "invalid modifier group")
+	ErrInvalidModifier            = apperror.Validation("INVALID_MODIFIER", "invalid modifier selection")
+	ErrTransactionNotFound        = apperror.NotFound("TRANSACTION_NOT_FOUND", "transaction not found")
+	ErrPromotionNotFound          = apperror.NotFound("PROMOTION_NOT_FOUND", "promotion not found")
+	ErrTransactionAlreadyRefunded = apperror.Conflict("TRANSACTION_ALREADY_REFUNDED", "transaction already fully refunded")
+	ErrInvalidRefundItem          = apperror.Validation("INVALID_REFUND_ITEM", "invalid refund item")
+	ErrEmptyCheckout              = apperror.Validation("EMPTY_CHECKOUT", "items cannot be empty")
+	ErrInvalidPayment             = apperror.Validation("INVALID_PAYMENT", "invalid payment")
+	ErrInvalidPromotion           = apperror.Validation("INVALID_PROMOTION", "invalid promotion")
+	ErrInvalidPromoCode           = apperror.Validation("INVALID_PROMO_CODE", "invalid promo code")
+	ErrInvalidIdempotencyKey      = apperror.Validation("INVALID_IDEMPOTENCY_KEY", "invalid idempotency key")
+	ErrIdempotencyKeyExists       = apperror.Conflict("IDEMPOTENCY_KEY_EXISTS", "idempotency key already exists")
+	ErrIdempotencyKeyReused       = apperror.Conflict("IDEMPOTENCY_KEY_REUSED", "idempotency key reused with a different request body")
+	ErrUnderpayment               = apperror.Validation("UNDERPAYMENT", "payment amount is less than total amount")
+	ErrUserNotFound               = apperror.NotFound("USER_NOT_FOUND", "user not found")
+	ErrUsernameTaken              = apperror.Conflict("USERNAME_TAKEN", "username already used")
+	ErrInvalidCredentials         = apperror.Unauthorized("INVALID_CREDENTIALS", "invalid username or password")
+	ErrUnauthenticated            = apperror.Unauthorized("UNAUTHENTICATED", "missing or invalid access token")
+	ErrTokenExpired               = apperror.Unauthorized("TOKEN_EXPIRED", "access token expired")
+	ErrInvalidRefreshToken        = apperror.Unauthorized("INVALID_REFRESH_TOKEN", "refresh token is invalid, expired or revoked")
+	ErrForbidden                  = apperror.Forbidden("FORBIDDEN", "your role is not allowed to access this resource")
+	ErrSelfLockout                = apperror.Validation("SELF_LOCKOUT", "admin cannot deactivate or demote their own account")
+	ErrShiftNotFound              = apperror.NotFound("SHIFT_NOT_FOUND", "shift not found")
+	ErrNoOpenShift                = apperror.Conflict("NO_OPEN_SHIFT", "no open shift for this cashier")
+	ErrShiftAlreadyOpen           = apperror.Conflict("SHIFT_ALREADY_OPEN", "cashier already has an open shift")
+	ErrShiftClosed                = apperror.Conflict("SHIFT_CLOSED", "shift already closed")
+	ErrStockOpnameNotFound        = apperror.NotFound("STOCK_OPNAME_NOT_FOUND", "stock opname not found")
+	ErrStockOpnameNotOpen         = apperror.Conflict("STOCK_OPNAME_NOT_OPEN", "stock opname already posted or cancelled")
+	ErrStockOpnameOverlap         = apperror.Conflict("STOCK_OPNAME_OVERLAP", "some products are already in another open stock opname")
+	ErrStockOpnameEmpty           = apperror.Validation("STOCK_OPNAME_EMPTY", "no product to count")
+	ErrInvalidStockOpnameItem     = apperror.Validation("INVALID_STOCK_OPNAME_ITEM", "product is not part of this stock opname")
+	ErrSupplierNotFound           = apperror.NotFound("SUPPLIER_NOT_FOUND", "supplier not found")
+	ErrSupplierNameTaken          = apperror.Conflict("SUPPLIER_NAME_TAKEN", "supplier name already used")
+	ErrSupplierInUse              = apperror.Conflict("SUPPLIER_IN_USE", "supplier already has purchase orders")
+	ErrPurchaseOrderNotFound      = apperror.NotFound("PURCHASE_ORDER_NOT_FOUND", "purchase order not found")
+	ErrPurchaseOrderStatus        = apperror.Conflict("INVALID_PURCHASE_ORDER_STATUS", "action not allowed for the current purchase order status")
+	ErrInvalidPurchaseOrderItem   = apperror.Validation("INVALID_PURCHASE_ORDER_ITEM", "invalid purchase order item")
+	ErrOverReceipt                = apperror.Validation("OVER_RECEIPT", "received quantity exceeds the remaining ordered quantity")
+)
@@ -0,0 +1,187 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	MaxMoneyAmount = 999_999_999_999
+	MinQuantity    = 1
+	MaxQuantity    = 10_000
+)
+
+// nomor HP Indonesia: 08xx, 628xx atau +628xx dengan total 10-13 digit
+var phoneIDRegex = regexp.MustCompile(`^(\+62|62|0)8[1-9][0-9]{6,10}$`)
+
+// Lookup dipakai rule yang butuh data dari database. excludeID diisi saat update
+// supaya data yang sedang diubah tidak dianggap duplikat.
+type Lookup interface {
+	CategoryExists(id string) (bool, error)
+	ProductNameExists(name, categoryID, excludeID string) (bool, error)
+	SKUExists(sku, excludeID string) (bool, error)
+	BarcodeExists(code, excludeID string) (bool, error)
+}
+
+// UseLookup memasang Lookup ke validator. Tanpa Lookup, rule yang butuh
+// database dianggap lolos.
+func (v *validatePkg) UseLookup(lookup Lookup) {
+	v.lookup = lookup
+}
+
+type lookupErrorKey struct{}
+
+// lookupError menampung error Lookup selama satu kali validasi
+type lookupError struct {
+	err error
+}
+
+// lookupFailed mencatat error Lookup supaya ValidateWithLang mengembalikannya sebagai
+// error server, bukan pesan validasi. Rule dianggap lolos karena hasilnya tidak diketahui
+func lookupFailed(ctx context.Context, err error) bool {
+	if holder, ok := ctx.Value(lookupErrorKey{}).(*lookupError); ok && holder.err == nil {
+		holder.err = fmt.Errorf("validation lookup failed: %w", err)
+	}
+
+	return true
+}
+
+func isMoney(fl validator.FieldLevel) bool {
+	switch fl.Field().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		amount := fl.Field().Int()
+		return amount > 0 && amount <= MaxMoneyAmount
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		amount := fl.Field().Uint()
+		return amount > 0 && amount <= MaxMoneyAmount
+	}
+
+	return false
+}
+
+func isQuantity(fl validator.FieldLevel) bool {
+	switch fl.Field().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		qty := fl.Field().Int()
+		return qty >= MinQuantity && qty <= MaxQuantity
+	}
+
+	return false
+}
+
+func isPhoneID(fl validator.FieldLevel) bool {
+	return phoneIDRegex.MatchString(fl.Field().String())
+}
+
+// isBarcode menerima EAN-8, UPC-A, EAN-13 dan GTIN-14 dengan check digit yang benar
+func isBarcode(fl validator.FieldLevel) bool {
+	code := fl.Field().String()
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+
+	// bobot 3 dan 1 bergantian dihitung dari digit paling kanan sebelum check digit
+	sum := 0
+	for i := len(code) - 1; i >= 0; i-- {
+		if code[i] < '0' || code[i] > '9' {
+			return false
+		}
+		if i == len(code)-1 {
+			continue
+		}
+
+		digit := int(code[i] - '0')
+		if (len(code)-1-i)%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
+
+// siblingString membaca field lain (string) pada struct yang sama, misalnya "ID" atau "CategoryID"
+func siblingString(fl validator.FieldLevel, name string) string {
+	parent := fl.Parent()
+	if parent.Kind() == reflect.Ptr {
+		parent = parent.Elem()
+	}
+
+	if parent.Kind() != reflect.Struct {
+		return ""
+	}
+
+	field := parent.FieldByName(name)
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+
+	return field.String()
+}
+
+func (v *validatePkg) categoryExists(ctx context.Context, fl validator.FieldLevel) bool {
+	if v.lookup == nil || fl.Field().String() == "" {
+		return true
+	}
+
+	exists, err := v.lookup.CategoryExists(fl.Field().String())
+	if err != nil {
+		return lookupFailed(ctx, err)
+	}
+
+	return exists
+}
+
+// uniqueProductName memastikan nama produk unik di dalam kategori yang sama,
+// kategori dibaca dari field CategoryID dan pengecualian dari field ID.
+// CategoryID yang bukan uuid dilewati, errornya dilaporkan oleh rule uuid/category_exists
+func (v *validatePkg) uniqueProductName(ctx context.Context, fl validator.FieldLevel) bool {
+	categoryID := siblingString(fl, "CategoryID")
+	if v.lookup == nil || categoryID == "" {
+		return true
+	}
+
+	if _, err := uuid.FromString(categoryID); err != nil {
+		return true
+	}
+
+	exists, err := v.lookup.ProductNameExists(fl.Field().String(), categoryID, siblingString(fl, "ID"))
+	if err != nil {
+		return lookupFailed(ctx, err)
+	}
+
+	return !exists
+}
+
+func (v *validatePkg) uniqueSKU(ctx context.Context, fl validator.FieldLevel) bool {
+	if v.lookup == nil || fl.Field().String() == "" {
+		return true
+	}
+
+	exists, err := v.lookup.SKUExists(fl.Field().String(), siblingString(fl, "ID"))
+	if err != nil {
+		return lookupFailed(ctx, err)
+	}
+
+	return !exists
+}
+
+func (v *validatePkg) uniqueBarcode(ctx context.Context, fl validator.FieldLevel) bool {
+	if v.lookup == nil || fl.Field().String() == "" {
+		return true
+	}
+
+	exists, err := v.lookup.BarcodeExists(fl.Field().String(), siblingString(fl, "ID"))
+	if err != nil {
+		return lookupFailed(ctx, err)
+	}
+
+	return !exists
+}
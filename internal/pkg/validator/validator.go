@@ -1,10 +1,15 @@
 package validator
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
 	"github.com/go-playground/locales/en"
 	"github.com/go-playground/locales/id"
 	ut "github.com/go-playground/universal-translator"
@@ -21,26 +26,50 @@ type ErrorValidate struct {
 func (er *ErrorValidate) Error() string {
 	var writeString strings.Builder
 
-	if er.errorValues != nil {
+	if len(er.errorValues) == 0 {
 		return "validation failed"
 	}
 
-	for k, v := range er.errorValues {
-		fmt.Fprintf(&writeString, "%s: %s \n", k, v)
+	keys := make([]string, 0, len(er.errorValues))
+	for k := range er.errorValues {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for i, k := range keys {
+		if i > 0 {
+			writeString.WriteString("; ")
+		}
+		fmt.Fprintf(&writeString, "%s: %s", k, er.errorValues[k])
 	}
 
 	return writeString.String()
 }
 
+func (er *ErrorValidate) Kind() apperror.Kind {
+	return apperror.KindValidation
+}
+
+func (er *ErrorValidate) Code() string {
+	return "VALIDATION_FAILED"
+}
+
+func (er *ErrorValidate) FieldErrors() map[string]string {
+	return er.errorValues
+}
+
 type ValidatePkg interface {
 	Validate(data any) error
+	ValidateWithLang(data any, lang string) error
 	ErrorMap(err error, lang string) map[string]string
+	UseLookup(lookup Lookup)
 }
 
 type validatePkg struct {
 	validate *validator.Validate
 	enTrans  ut.Translator
 	idTrans  ut.Translator
+	lookup   Lookup
 }
 
 var (
@@ -51,6 +80,17 @@ var (
 func NewValidation() ValidatePkg {
 	once.Do(func() {
 		validate := validator.New(validator.WithRequiredStructEnabled())
+		// gunakan nama field json supaya key error sama dengan body request
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
 
 		en := en.New()
 		id := id.New()
@@ -86,25 +126,52 @@ func (v *validatePkg) ErrorMap(err error, lang string) map[string]string {
 		return errs.errorValues
 	}
 
-	trans := v.enTrans
+	if validationErrs, ok := err.(validator.ValidationErrors); ok {
+		return v.translate(validationErrs, v.translator(lang))
+	}
 
-	switch lang {
-	case "id", "ID", "id-ID", "id_ID":
-		lang = "id"
+	return nil
+}
+
+// translator memilih translator dari kode bahasa, contoh "id", "id-ID" atau "en-US"
+func (v *validatePkg) translator(lang string) ut.Translator {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+
+	if lang == "id" || strings.HasPrefix(lang, "id-") || strings.HasPrefix(lang, "id_") {
+		return v.idTrans
 	}
 
-	if validationErrs, ok := err.(validator.ValidationErrors); ok {
-		return validationErrs.Translate(trans)
+	return v.enTrans
+}
+
+// translate membuat map error dengan key path field json tanpa nama struct,
+// contoh "items[0].quantity"
+func (v *validatePkg) translate(errs validator.ValidationErrors, trans ut.Translator) map[string]string {
+	result := make(map[string]string, len(errs))
+
+	for _, fe := range errs {
+		key := fe.Namespace()
+		if _, field, found := strings.Cut(key, "."); found {
+			key = field
+		}
+		result[key] = fe.Translate(trans)
 	}
 
-	return nil
+	return result
 }
 
 /*
 register custom validation
 */
 func (v *validatePkg) registerValidation() {
-
+	v.validate.RegisterValidation("money", isMoney)
+	v.validate.RegisterValidation("qty", isQuantity)
+	v.validate.RegisterValidation("phone_id", isPhoneID)
+	v.validate.RegisterValidation("barcode", isBarcode)
+	v.validate.RegisterValidationCtx("category_exists", v.categoryExists)
+	v.validate.RegisterValidationCtx("unique_product_name", v.uniqueProductName)
+	v.validate.RegisterValidationCtx("unique_sku", v.uniqueSKU)
+	v.validate.RegisterValidationCtx("unique_barcode", v.uniqueBarcode)
 }
 
 /*
@@ -114,25 +181,60 @@ func (v *validatePkg) registerTranslation() {
 	/*
 		register your translate "en" of validation here
 	*/
+	v.addTranslation(v.enTrans, map[string]string{
+		"money":               "{0} must be a positive amount not greater than " + strconv.FormatInt(MaxMoneyAmount, 10),
+		"qty":                 "{0} must be between " + strconv.Itoa(MinQuantity) + " and " + strconv.Itoa(MaxQuantity),
+		"phone_id":            "{0} must be a valid Indonesian phone number",
+		"barcode":             "{0} must be a valid EAN/UPC barcode",
+		"category_exists":     "{0} does not refer to an existing category",
+		"unique_product_name": "{0} is already used by another product in this category",
+		"unique_sku":          "{0} is already used by another product",
+		"unique_barcode":      "{0} is already used by another product",
+	})
 
 	/*
 		register your translate "id" of validation here
 	*/
+	v.addTranslation(v.idTrans, map[string]string{
+		"money":               "{0} harus berupa nominal positif dan tidak lebih dari " + strconv.FormatInt(MaxMoneyAmount, 10),
+		"qty":                 "{0} harus di antara " + strconv.Itoa(MinQuantity) + " dan " + strconv.Itoa(MaxQuantity),
+		"phone_id":            "{0} harus berupa nomor HP Indonesia yang valid",
+		"barcode":             "{0} harus berupa barcode EAN/UPC yang valid",
+		"category_exists":     "{0} tidak merujuk ke kategori yang ada",
+		"unique_product_name": "{0} sudah dipakai produk lain di kategori ini",
+		"unique_sku":          "{0} sudah dipakai produk lain",
+		"unique_barcode":      "{0} sudah dipakai produk lain",
+	})
+}
+
+func (v *validatePkg) addTranslation(trans ut.Translator, messages map[string]string) {
+	for tag, message := range messages {
+		v.validate.RegisterTranslation(tag, trans,
+			func(ut ut.Translator) error {
+				return ut.Add(tag, message, true)
+			},
+			func(ut ut.Translator, fe validator.FieldError) string {
+				t, _ := ut.T(tag, fe.Field())
+				return t
+			},
+		)
+	}
 }
 
 // validate struct and return error map
 func (v *validatePkg) ValidateWithLang(data any, lang string) error {
-	trans := v.enTrans
+	lookupErr := &lookupError{}
+	err := v.validate.StructCtx(context.WithValue(context.Background(), lookupErrorKey{}, lookupErr), data)
 
-	if lang == "id" {
-		trans = v.idTrans
+	// database gagal dibaca adalah error server, bukan kesalahan input
+	if lookupErr.err != nil {
+		return lookupErr.err
 	}
-	err := v.validate.Struct(data)
 
 	if err != nil {
 		if errs, ok := err.(validator.ValidationErrors); ok {
 			return &ErrorValidate{
-				errorValues: errs.Translate(trans),
+				errorValues: v.translate(errs, v.translator(lang)),
 			}
 		}
 
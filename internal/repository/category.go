@@ -2,46 +2,56 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/Muh-Sidik/kasir-api/database"
 	"github.com/Muh-Sidik/kasir-api/internal/model"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/request"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
 )
 
 type CategoryRepository interface {
-	GetCategories(paginate *request.PaginateQuery, search string) ([]*model.Categories, int, error)
+	GetCategories(paginate *request.PaginateQuery, search string, includeArchived bool) ([]*model.Categories, int, error)
 	GetCategoryByID(id string) (*model.Categories, error)
 	CreateCategory(category *model.Categories) (*model.Categories, error)
 	UpdateCategoryByID(id string, category *model.Categories) (*model.Categories, error)
 	DeleteCategoryByID(id string) error
+	RestoreCategoryByID(id string) (*model.Categories, error)
 }
 
 type categoryRepo struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect database.Dialect
 }
 
-func NewCategoryRepository(db *sql.DB) CategoryRepository {
+func NewCategoryRepository(db *sql.DB, dialect database.Dialect) CategoryRepository {
 	return &categoryRepo{
-		db: db,
+		db:      db,
+		dialect: dialect,
 	}
 }
 
-func (c *categoryRepo) GetCategories(paginate *request.PaginateQuery, search string) ([]*model.Categories, int, error) {
+func (c *categoryRepo) GetCategories(paginate *request.PaginateQuery, search string, includeArchived bool) ([]*model.Categories, int, error) {
 	var whereClause strings.Builder
 	var args []any
 	argsIdx := 1
 
 	whereClause.WriteString("WHERE 1=1 ")
 
+	if !includeArchived {
+		whereClause.WriteString(" AND deleted_at IS NULL")
+	}
+
 	if search != "" {
-		fmt.Fprintf(&whereClause, " AND name ILIKE $%d", argsIdx)
+		fmt.Fprintf(&whereClause, " AND LOWER(name) LIKE LOWER($%d)", argsIdx)
 		args = append(args, "%"+search+"%")
 		argsIdx++
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, name, description, created_at, updated_at 
+		SELECT id, name, description, tax_exempt, created_at, updated_at, deleted_at
 		FROM categories 
 		%s
 		LIMIT $%d OFFSET $%d`, whereClause.String(), argsIdx, argsIdx+1)
@@ -63,8 +73,10 @@ func (c *categoryRepo) GetCategories(paginate *request.PaginateQuery, search str
 			&category.ID,
 			&category.Name,
 			&category.Description,
+			&category.TaxExempt,
 			&category.CreatedAt,
 			&category.UpdatedAt,
+			&category.DeletedAt,
 		)
 
 		if err != nil {
@@ -97,10 +109,11 @@ func (c *categoryRepo) GetCategories(paginate *request.PaginateQuery, search str
 
 func (c *categoryRepo) CreateCategory(body *model.Categories) (*model.Categories, error) {
 	rows := c.db.QueryRow(
-		`INSERT INTO categories(id, name, description, created_at, updated_at) VALUES($1,$2,$3,NOW(),NOW()) RETURNING id, created_at, updated_at`,
+		fmt.Sprintf(`INSERT INTO categories(id, name, description, tax_exempt, created_at, updated_at) VALUES($1,$2,$3,$4,%[1]s,%[1]s) RETURNING id, created_at, updated_at`, c.dialect.Now()),
 		body.ID,
 		body.Name,
 		body.Description,
+		body.TaxExempt,
 	)
 
 	if rows.Err() != nil {
@@ -118,12 +131,13 @@ func (c *categoryRepo) CreateCategory(body *model.Categories) (*model.Categories
 
 	category.Name = body.Name
 	category.Description = body.Description
+	category.TaxExempt = body.TaxExempt
 
 	return &category, nil
 }
 
 func (c *categoryRepo) GetCategoryByID(id string) (*model.Categories, error) {
-	query := `SELECT id, name, description, created_at, updated_at FROM categories WHERE id = $1`
+	query := `SELECT id, name, description, tax_exempt, created_at, updated_at, deleted_at FROM categories WHERE id = $1`
 
 	rows := c.db.QueryRow(query, id)
 
@@ -136,35 +150,94 @@ func (c *categoryRepo) GetCategoryByID(id string) (*model.Categories, error) {
 		&category.ID,
 		&category.Name,
 		&category.Description,
+		&category.TaxExempt,
 		&category.CreatedAt,
 		&category.UpdatedAt,
+		&category.DeletedAt,
 	)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrCategoryNotFound
+		}
 		return nil, err
 	}
 
 	return &category, nil
 }
 
+// DeleteCategoryByID mengarsipkan kategori, produk aktif di dalamnya harus diarsipkan lebih dulu
 func (c *categoryRepo) DeleteCategoryByID(id string) error {
+	var used bool
+	err := c.db.QueryRow("SELECT EXISTS(SELECT 1 FROM product WHERE category_id = $1 AND deleted_at IS NULL)", id).Scan(&used)
+	if err != nil {
+		return err
+	}
+
+	if used {
+		return utils.ErrCategoryInUse
+	}
+
+	result, err := c.db.Exec(
+		fmt.Sprintf(`UPDATE categories SET deleted_at = %[1]s, updated_at = %[1]s WHERE id = $1 AND deleted_at IS NULL`, c.dialect.Now()),
+		id,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ensureActiveCategory(c.db, id)
+	}
+
+	return nil
+}
+
+// RestoreCategoryByID mengaktifkan kembali kategori, produk di dalamnya tetap diarsipkan
+// sampai di-restore satu per satu
+func (c *categoryRepo) RestoreCategoryByID(id string) (*model.Categories, error) {
 	_, err := c.db.Exec(
-		`DELETE FROM categories WHERE id = $1`,
+		fmt.Sprintf(`UPDATE categories SET deleted_at = NULL, updated_at = %s WHERE id = $1 AND deleted_at IS NOT NULL`, c.dialect.Now()),
 		id,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetCategoryByID(id)
+}
 
+// ensureActiveCategory mengembalikan ErrCategoryNotFound jika kategori tidak ada
+// dan ErrCategoryArchived jika kategori sudah diarsipkan
+func ensureActiveCategory(q queryRower, id any) error {
+	var archived bool
+	err := q.QueryRow("SELECT deleted_at IS NOT NULL FROM categories WHERE id = $1", id).Scan(&archived)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.ErrCategoryNotFound
+		}
 		return err
 	}
 
+	if archived {
+		return utils.ErrCategoryArchived
+	}
+
 	return nil
 }
 
 func (c *categoryRepo) UpdateCategoryByID(id string, body *model.Categories) (*model.Categories, error) {
 	rows := c.db.QueryRow(
-		`UPDATE categories SET name = $1, description = $2, updated_at = NOW() WHERE id = $3 RETURNING id, created_at, updated_at`,
+		fmt.Sprintf(`UPDATE categories SET name = $1, description = $2, tax_exempt = $3, updated_at = %s WHERE id = $4 AND deleted_at IS NULL RETURNING id, created_at, updated_at`, c.dialect.Now()),
 		body.Name,
 		body.Description,
+		body.TaxExempt,
 		id,
 	)
 
@@ -178,11 +251,18 @@ func (c *categoryRepo) UpdateCategoryByID(id string, body *model.Categories) (*m
 		&category.CreatedAt,
 		&category.UpdatedAt,
 	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if err := ensureActiveCategory(c.db, id); err != nil {
+				return nil, err
+			}
+			return nil, utils.ErrCategoryNotFound
+		}
 		return nil, err
 	}
 
 	category.Name = body.Name
 	category.Description = body.Description
+	category.TaxExempt = body.TaxExempt
 
 	return &category, nil
 }
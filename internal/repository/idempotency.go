@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/bytedance/sonic"
+	"github.com/gofrs/uuid/v5"
+)
+
+// reserveIdempotencyKey menyimpan key di dalam transaksi checkout yang sama.
+// Jika ada request paralel dengan key yang sama, insert akan menunggu transaksi
+// lain selesai lalu tidak menyisipkan apa-apa, sehingga checkout kedua dibatalkan.
+func reserveIdempotencyKey(tx *sql.Tx, dialect database.Dialect, key *model.IdempotencyKey) (bool, error) {
+	result, err := tx.Exec(
+		fmt.Sprintf(`INSERT INTO idempotency_keys (cashier_id, key, request_hash, created_at)
+		VALUES ($1,$2,$3,%s)
+		ON CONFLICT (cashier_id, key) DO NOTHING`, dialect.Now()),
+		key.CashierID,
+		key.Key,
+		key.RequestHash,
+	)
+	if err != nil {
+		return false, fmt.Errorf("reserve idempotency key failed: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func completeIdempotencyKey(tx *sql.Tx, key *model.IdempotencyKey, transaction *model.Transaction) error {
+	resp, err := sonic.Marshal(transaction)
+	if err != nil {
+		return fmt.Errorf("marshal idempotent response failed: %w", err)
+	}
+
+	_, err = tx.Exec(
+		`UPDATE idempotency_keys SET transaction_id = $1, response = $2 WHERE cashier_id = $3 AND key = $4`,
+		transaction.ID,
+		resp,
+		key.CashierID,
+		key.Key,
+	)
+	if err != nil {
+		return fmt.Errorf("store idempotent response failed: %w", err)
+	}
+
+	return nil
+}
+
+func (t *transactionRepository) FindIdempotencyKey(cashierID uuid.UUID, key string) (*model.IdempotencyKey, error) {
+	var record model.IdempotencyKey
+	err := t.db.QueryRow(
+		`SELECT cashier_id, key, request_hash, transaction_id, response, created_at
+		FROM idempotency_keys
+		WHERE cashier_id = $1 AND key = $2 AND transaction_id IS NOT NULL`,
+		cashierID,
+		key,
+	).Scan(
+		&record.CashierID,
+		&record.Key,
+		&record.RequestHash,
+		&record.TransactionID,
+		&record.Response,
+		&record.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
@@ -0,0 +1,601 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/gofrs/uuid/v5"
+)
+
+type ModifierRepository interface {
+	GetModifierGroups(query *dto.ModifierGroupQuery) ([]*model.ModifierGroup, int, error)
+	GetModifierGroupByID(id string) (*model.ModifierGroup, error)
+	CreateModifierGroup(group *model.ModifierGroup) (*model.ModifierGroup, error)
+	UpdateModifierGroupByID(id string, group *model.ModifierGroup) (*model.ModifierGroup, error)
+	DeleteModifierGroupByID(id string) error
+}
+
+type modifierRepo struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewModifierRepository(db *sql.DB, dialect database.Dialect) ModifierRepository {
+	return &modifierRepo{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+const modifierGroupColumns = `
+	g.id,
+	g.name,
+	g.min_select,
+	g.max_select,
+	g.created_at,
+	g.updated_at`
+
+func scanModifierGroup(row rowScanner) (*model.ModifierGroup, error) {
+	var group model.ModifierGroup
+	err := row.Scan(
+		&group.ID,
+		&group.Name,
+		&group.MinSelect,
+		&group.MaxSelect,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrModifierGroupNotFound
+		}
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (m *modifierRepo) GetModifierGroups(query *dto.ModifierGroupQuery) ([]*model.ModifierGroup, int, error) {
+	var whereClause strings.Builder
+	var args []any
+	argsIdx := 1
+
+	whereClause.WriteString("WHERE 1=1 ")
+
+	if query.Name != "" {
+		fmt.Fprintf(&whereClause, " AND LOWER(g.name) LIKE LOWER($%d)", argsIdx)
+		args = append(args, "%"+query.Name+"%")
+		argsIdx++
+	}
+
+	if query.ProductID != "" {
+		fmt.Fprintf(&whereClause, " AND %s", modifierGroupAppliesTo(fmt.Sprintf("$%d", argsIdx)))
+		args = append(args, query.ProductID)
+		argsIdx++
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT %s
+		FROM modifier_groups g
+		%s
+		ORDER BY g.name
+		LIMIT $%d OFFSET $%d`, modifierGroupColumns, whereClause.String(), argsIdx, argsIdx+1),
+		append(args, query.Limit, query.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	groups := make([]*model.ModifierGroup, 0)
+	for rows.Next() {
+		group, err := scanModifierGroup(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		groups = append(groups, group)
+	}
+
+	if rows.Err() != nil {
+		return nil, 0, rows.Err()
+	}
+
+	if err := attachModifiers(m.db, groups); err != nil {
+		return nil, 0, err
+	}
+
+	if err := attachModifierTargets(m.db, groups); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	err = m.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM modifier_groups g %s`, whereClause.String()),
+		args...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return groups, total, nil
+}
+
+func (m *modifierRepo) GetModifierGroupByID(id string) (*model.ModifierGroup, error) {
+	return getModifierGroup(m.db, id)
+}
+
+// getModifierGroup mengambil group beserta modifier dan produk/kategori yang dipasang
+func getModifierGroup(q queryer, id string) (*model.ModifierGroup, error) {
+	group, err := scanModifierGroup(q.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM modifier_groups g WHERE g.id = $1`, modifierGroupColumns),
+		id,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []*model.ModifierGroup{group}
+	if err := attachModifiers(q, groups); err != nil {
+		return nil, err
+	}
+
+	if err := attachModifierTargets(q, groups); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (m *modifierRepo) CreateModifierGroup(group *model.ModifierGroup) (*model.ModifierGroup, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`INSERT INTO modifier_groups (id, name, min_select, max_select, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,%[1]s,%[1]s)`, m.dialect.Now()),
+		group.ID,
+		group.Name,
+		group.MinSelect,
+		group.MaxSelect,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveModifierGroupItems(tx, group); err != nil {
+		return nil, err
+	}
+
+	created, err := getModifierGroup(tx, group.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (m *modifierRepo) UpdateModifierGroupByID(id string, group *model.ModifierGroup) (*model.ModifierGroup, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(
+		fmt.Sprintf(`UPDATE modifier_groups SET name = $1, min_select = $2, max_select = $3, updated_at = %s
+		WHERE id = $4
+		RETURNING id`, m.dialect.Now()),
+		group.Name,
+		group.MinSelect,
+		group.MaxSelect,
+		id,
+	).Scan(&group.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrModifierGroupNotFound
+		}
+		return nil, err
+	}
+
+	if err := saveModifierGroupItems(tx, group); err != nil {
+		return nil, err
+	}
+
+	updated, err := getModifierGroup(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteModifierGroupByID menghapus group beserta modifier dan pemasangannya (ON DELETE CASCADE).
+// Riwayat transaksi aman karena modifier yang terjual disimpan sebagai snapshot
+func (m *modifierRepo) DeleteModifierGroupByID(id string) error {
+	result, err := m.db.Exec(`DELETE FROM modifier_groups WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return utils.ErrModifierGroupNotFound
+	}
+
+	return nil
+}
+
+// saveModifierGroupItems menyimpan modifier dan pemasangan group. Modifier dengan id lama
+// diperbarui, yang baru ditambahkan dan yang tidak dikirim lagi dihapus
+func saveModifierGroupItems(tx *sql.Tx, group *model.ModifierGroup) error {
+	rows, err := tx.Query(`SELECT id FROM modifiers WHERE group_id = $1`, group.ID)
+	if err != nil {
+		return fmt.Errorf("query modifiers failed: %w", err)
+	}
+
+	existing := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan modifier failed: %w", err)
+		}
+		existing[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	kept := make(map[uuid.UUID]bool)
+	for i, modifier := range group.Modifiers {
+		if !modifier.ID.IsNil() && !existing[modifier.ID] {
+			return fmt.Errorf("%w: modifier %s does not belong to this group", utils.ErrInvalidModifierGroup, modifier.ID)
+		}
+
+		if existing[modifier.ID] {
+			_, err = tx.Exec(
+				`UPDATE modifiers SET name = $1, price_delta = $2, position = $3 WHERE id = $4`,
+				modifier.Name,
+				modifier.PriceDelta,
+				i+1,
+				modifier.ID,
+			)
+		} else {
+			modifier.ID, err = uuid.NewV7()
+			if err != nil {
+				return fmt.Errorf("generate modifier id failed: %w", err)
+			}
+
+			_, err = tx.Exec(
+				`INSERT INTO modifiers (id, group_id, name, price_delta, position) VALUES ($1,$2,$3,$4,$5)`,
+				modifier.ID,
+				group.ID,
+				modifier.Name,
+				modifier.PriceDelta,
+				i+1,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("save modifier failed: %w", err)
+		}
+		kept[modifier.ID] = true
+	}
+
+	for id := range existing {
+		if kept[id] {
+			continue
+		}
+		if _, err := tx.Exec(`DELETE FROM modifiers WHERE id = $1`, id); err != nil {
+			return fmt.Errorf("delete modifier failed: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM modifier_group_products WHERE group_id = $1`, group.ID); err != nil {
+		return fmt.Errorf("delete modifier group products failed: %w", err)
+	}
+
+	for _, productID := range group.ProductIDs {
+		if err := ensureActiveProduct(tx, productID); err != nil {
+			return fmt.Errorf("%w: %s", err, productID)
+		}
+
+		_, err := tx.Exec(`INSERT INTO modifier_group_products (group_id, product_id) VALUES ($1,$2)`, group.ID, productID)
+		if err != nil {
+			return fmt.Errorf("insert modifier group product failed: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM modifier_group_categories WHERE group_id = $1`, group.ID); err != nil {
+		return fmt.Errorf("delete modifier group categories failed: %w", err)
+	}
+
+	for _, categoryID := range group.CategoryIDs {
+		if err := ensureActiveCategory(tx, categoryID); err != nil {
+			return fmt.Errorf("%w: %s", err, categoryID)
+		}
+
+		_, err := tx.Exec(`INSERT INTO modifier_group_categories (group_id, category_id) VALUES ($1,$2)`, group.ID, categoryID)
+		if err != nil {
+			return fmt.Errorf("insert modifier group category failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// modifierGroupAppliesTo adalah kondisi untuk modifier_groups g: group dipasang ke produk,
+// ke produk induknya (untuk varian) atau ke kategorinya. product adalah ekspresi id produk
+func modifierGroupAppliesTo(product string) string {
+	return fmt.Sprintf(`(
+		EXISTS(
+			SELECT 1 FROM modifier_group_products gp
+			JOIN product p ON gp.product_id = p.id OR gp.product_id = p.parent_id
+			WHERE gp.group_id = g.id AND p.id = %[1]s
+		)
+		OR EXISTS(
+			SELECT 1 FROM modifier_group_categories gc
+			JOIN product p ON gc.category_id = p.category_id
+			WHERE gc.group_id = g.id AND p.id = %[1]s
+		)
+	)`, product)
+}
+
+// productModifierGroups mengambil modifier group yang berlaku untuk tiap produk,
+// group yang sama dipakai bersama (pointer yang sama) oleh beberapa produk
+func productModifierGroups(q queryer, productIDs []uuid.UUID) (map[uuid.UUID][]*model.ModifierGroup, error) {
+	result := make(map[uuid.UUID][]*model.ModifierGroup)
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	args := make([]any, len(productIDs))
+	placeholders := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		args[i] = id
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	rows, err := q.Query(fmt.Sprintf(`
+		SELECT t.id, %s
+		FROM product t
+		JOIN modifier_groups g ON %s
+		WHERE t.id IN (%s)
+		ORDER BY g.name, g.id`, modifierGroupColumns, modifierGroupAppliesTo("t.id"), strings.Join(placeholders, ",")),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query product modifier groups failed: %w", err)
+	}
+	defer rows.Close()
+
+	groups := make(map[uuid.UUID]*model.ModifierGroup)
+	var unique []*model.ModifierGroup
+	for rows.Next() {
+		var productID uuid.UUID
+		var group model.ModifierGroup
+		if err := rows.Scan(
+			&productID,
+			&group.ID,
+			&group.Name,
+			&group.MinSelect,
+			&group.MaxSelect,
+			&group.CreatedAt,
+			&group.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan product modifier group failed: %w", err)
+		}
+
+		shared, ok := groups[group.ID]
+		if !ok {
+			shared = &group
+			groups[group.ID] = shared
+			unique = append(unique, shared)
+		}
+		result[productID] = append(result[productID], shared)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := attachModifiers(q, unique); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// attachModifiers mengisi modifier untuk banyak group sekaligus, urut sesuai position
+func attachModifiers(q queryer, groups []*model.ModifierGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*model.ModifierGroup, len(groups))
+	args := make([]any, len(groups))
+	placeholders := make([]string, len(groups))
+	for i, group := range groups {
+		group.Modifiers = make([]model.Modifier, 0)
+		byID[group.ID] = group
+		args[i] = group.ID
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	rows, err := q.Query(fmt.Sprintf(`
+		SELECT group_id, id, name, price_delta
+		FROM modifiers
+		WHERE group_id IN (%s)
+		ORDER BY position`, strings.Join(placeholders, ",")),
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("query modifiers failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupID uuid.UUID
+		var modifier model.Modifier
+		if err := rows.Scan(&groupID, &modifier.ID, &modifier.Name, &modifier.PriceDelta); err != nil {
+			return fmt.Errorf("scan modifier failed: %w", err)
+		}
+		byID[groupID].Modifiers = append(byID[groupID].Modifiers, modifier)
+	}
+
+	return rows.Err()
+}
+
+// attachModifierTargets mengisi produk dan kategori tempat group dipasang
+func attachModifierTargets(q queryer, groups []*model.ModifierGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*model.ModifierGroup, len(groups))
+	args := make([]any, len(groups))
+	placeholders := make([]string, len(groups))
+	for i, group := range groups {
+		byID[group.ID] = group
+		args[i] = group.ID
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	rows, err := q.Query(fmt.Sprintf(`
+		SELECT group_id, product_id, 'product' FROM modifier_group_products WHERE group_id IN (%[1]s)
+		UNION ALL
+		SELECT group_id, category_id, 'category' FROM modifier_group_categories WHERE group_id IN (%[1]s)`,
+		strings.Join(placeholders, ",")),
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("query modifier group targets failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupID, targetID uuid.UUID
+		var kind string
+		if err := rows.Scan(&groupID, &targetID, &kind); err != nil {
+			return fmt.Errorf("scan modifier group target failed: %w", err)
+		}
+
+		group := byID[groupID]
+		if kind == "product" {
+			group.ProductIDs = append(group.ProductIDs, targetID)
+		} else {
+			group.CategoryIDs = append(group.CategoryIDs, targetID)
+		}
+	}
+
+	return rows.Err()
+}
+
+// resolveModifiers memvalidasi modifier yang dipilih per item checkout: modifier harus berasal
+// dari group yang berlaku untuk produknya dan jumlah pilihan per group memenuhi min/max.
+// Hasilnya snapshot modifier per item dengan urutan sesuai pilihan kasir
+func resolveModifiers(q queryer, products []checkoutProduct, items []dto.CheckoutItem) ([][]model.TransactionDetailModifier, error) {
+	productIDs := make([]uuid.UUID, 0, len(products))
+	seen := make(map[uuid.UUID]bool)
+	for _, product := range products {
+		if !seen[product.ID] {
+			seen[product.ID] = true
+			productIDs = append(productIDs, product.ID)
+		}
+	}
+
+	groupsByProduct, err := productModifierGroups(q, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	type choice struct {
+		group    *model.ModifierGroup
+		modifier model.Modifier
+	}
+
+	results := make([][]model.TransactionDetailModifier, len(items))
+	for i, item := range items {
+		product := products[i]
+		groups := groupsByProduct[product.ID]
+
+		available := make(map[uuid.UUID]choice)
+		for _, group := range groups {
+			for _, modifier := range group.Modifiers {
+				available[modifier.ID] = choice{group: group, modifier: modifier}
+			}
+		}
+
+		selected := make(map[uuid.UUID]int)
+		for _, modifierID := range item.Modifiers {
+			c, ok := available[modifierID]
+			if !ok {
+				return nil, fmt.Errorf("%w: modifier %s is not available for %s", utils.ErrInvalidModifier, modifierID, product.Name)
+			}
+
+			selected[c.group.ID]++
+			results[i] = append(results[i], model.TransactionDetailModifier{
+				ModifierID: c.modifier.ID,
+				GroupName:  c.group.Name,
+				Name:       c.modifier.Name,
+				PriceDelta: c.modifier.PriceDelta,
+			})
+		}
+
+		for _, group := range groups {
+			count := selected[group.ID]
+			if count < group.MinSelect {
+				return nil, fmt.Errorf("%w: %s requires at least %d %s", utils.ErrInvalidModifier, product.Name, group.MinSelect, group.Name)
+			}
+			if count > group.MaxSelect {
+				return nil, fmt.Errorf("%w: %s allows at most %d %s", utils.ErrInvalidModifier, product.Name, group.MaxSelect, group.Name)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// insertDetailModifiers menyimpan snapshot modifier untuk semua detail transaksi
+func insertDetailModifiers(tx *sql.Tx, details []model.TransactionDetail) error {
+	for _, detail := range details {
+		for i, modifier := range detail.Modifiers {
+			_, err := tx.Exec(
+				`INSERT INTO transaction_detail_modifiers (transaction_detail_id, position, modifier_id, group_name, name, price_delta)
+				VALUES ($1,$2,$3,$4,$5,$6)`,
+				detail.ID,
+				i+1,
+				modifier.ModifierID,
+				modifier.GroupName,
+				modifier.Name,
+				modifier.PriceDelta,
+			)
+			if err != nil {
+				return fmt.Errorf("insert transaction detail modifier failed: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/gofrs/uuid/v5"
+)
+
+// settlePayments memvalidasi tender terhadap total belanja dan menghitung kembalian.
+// Kembalian hanya boleh berasal dari tunai, jadi pembayaran non-tunai tidak boleh melebihi total.
+func settlePayments(transactionID uuid.UUID, totalAmount int64, payments []dto.CheckoutPayment) ([]model.TransactionPayment, int64, int64, error) {
+	if len(payments) == 0 {
+		return nil, 0, 0, fmt.Errorf("%w: payments cannot be empty", utils.ErrUnderpayment)
+	}
+
+	var paidAmount, nonCashAmount int64
+	for _, p := range payments {
+		if !slices.Contains(model.PaymentMethods, p.Method) {
+			return nil, 0, 0, fmt.Errorf("%w: unsupported method %q", utils.ErrInvalidPayment, p.Method)
+		}
+		if p.Amount <= 0 {
+			return nil, 0, 0, fmt.Errorf("%w: amount must be greater than 0", utils.ErrInvalidPayment)
+		}
+
+		paidAmount += p.Amount
+		if p.Method != model.PaymentMethodCash {
+			nonCashAmount += p.Amount
+		}
+	}
+
+	if paidAmount < totalAmount {
+		return nil, 0, 0, fmt.Errorf("%w: total %d, paid %d", utils.ErrUnderpayment, totalAmount, paidAmount)
+	}
+
+	if nonCashAmount > totalAmount {
+		return nil, 0, 0, fmt.Errorf("%w: non-cash payment %d exceeds total %d", utils.ErrInvalidPayment, nonCashAmount, totalAmount)
+	}
+
+	changeAmount := paidAmount - totalAmount
+	remainingChange := changeAmount
+
+	result := make([]model.TransactionPayment, 0, len(payments))
+	for _, p := range payments {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("generate payment id failed: %w", err)
+		}
+
+		// alokasikan kembalian ke baris tunai secara berurutan
+		var change int64
+		if p.Method == model.PaymentMethodCash && remainingChange > 0 {
+			change = min(p.Amount, remainingChange)
+			remainingChange -= change
+		}
+
+		result = append(result, model.TransactionPayment{
+			ID:            id,
+			TransactionID: transactionID,
+			Method:        p.Method,
+			Amount:        p.Amount,
+			ChangeAmount:  change,
+			Reference:     p.Reference,
+		})
+	}
+
+	return result, paidAmount, changeAmount, nil
+}
+
+func bulkInsertPayments(tx *sql.Tx, payments []model.TransactionPayment) error {
+	if len(payments) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(payments))
+	args := make([]any, 0, len(payments)*7)
+	for i, p := range payments {
+		args = append(args, p.ID, p.TransactionID, p.Method, p.Amount, p.ChangeAmount, p.Reference, p.CreatedAt)
+		valueStrings[i] = fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d,$%d)", i*7+1, i*7+2, i*7+3, i*7+4, i*7+5, i*7+6, i*7+7)
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO transaction_payments (id,transaction_id,method,amount,change_amount,reference,created_at) VALUES %s",
+		strings.Join(valueStrings, ","),
+	)
+
+	_, err := tx.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("bulk insert payments failed: %w", err)
+	}
+	return nil
+}
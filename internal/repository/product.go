@@ -2,29 +2,42 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/Muh-Sidik/kasir-api/database"
 	"github.com/Muh-Sidik/kasir-api/internal/model"
 	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/gofrs/uuid/v5"
 )
 
 type ProductRepository interface {
 	GetProduct(dto *dto.ProductQuery) ([]*model.ProductCategory, int, error)
-	CreateProduct(body *model.Product) (*model.Product, error)
+	CreateProduct(body *model.Product, userID *uuid.UUID) (*model.Product, error)
 	GetProductByID(id string) (*model.ProductCategory, error)
+	GetProductByBarcode(code string) (*model.ProductCategory, error)
 	DeleteProductByID(id string) error
-	UpdateProductByID(id string, body *model.Product) (*model.Product, error)
+	RestoreProductByID(id string) (*model.ProductCategory, error)
+	UpdateProductByID(id string, body *model.Product, stock *int, cost *int64, userID *uuid.UUID) (*model.Product, error)
+	CreateVariant(parentID string, body *model.Product, userID *uuid.UUID) (*model.Product, error)
+	UpdateVariant(parentID, variantID string, body *model.Product, stock *int, cost *int64, userID *uuid.UUID) (*model.Product, error)
+	GetStockMovements(productID string, query *dto.StockMovementQuery) ([]*model.StockMovement, int, error)
+	CreateStockMovement(movement *model.StockMovement) (*model.StockMovement, error)
+	GetLowStockProducts(query *dto.LowStockQuery) ([]*model.LowStockProduct, int, error)
+	GetLabelProducts(query *dto.LabelRequest) ([]*model.ProductCategory, error)
 }
 
 type productRepo struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect database.Dialect
 }
 
-func NewProductRepository(db *sql.DB) ProductRepository {
+func NewProductRepository(db *sql.DB, dialect database.Dialect) ProductRepository {
 	return &productRepo{
-		db: db,
+		db:      db,
+		dialect: dialect,
 	}
 }
 
@@ -33,7 +46,12 @@ func (p *productRepo) GetProduct(dto *dto.ProductQuery) ([]*model.ProductCategor
 	var args []any
 	argsIdx := 1
 
-	whereClause.WriteString("WHERE 1=1 ")
+	// varian tidak tampil sendiri, tapi dikelompokkan di bawah produk induknya
+	whereClause.WriteString("WHERE p.parent_id IS NULL ")
+
+	if !dto.IncludeArchived {
+		whereClause.WriteString(" AND p.deleted_at IS NULL")
+	}
 
 	if dto.CategoryID != "" {
 		fmt.Fprintf(&whereClause, " AND p.category_id = $%d", argsIdx)
@@ -42,23 +60,36 @@ func (p *productRepo) GetProduct(dto *dto.ProductQuery) ([]*model.ProductCategor
 	}
 
 	if dto.Name != "" {
-		fmt.Fprintf(&whereClause, " AND p.name ILIKE $%d", argsIdx)
-		args = append(args, "%"+dto.Name+"%")
-		argsIdx++
+		fmt.Fprintf(
+			&whereClause,
+			` AND (LOWER(p.name) LIKE LOWER($%[1]d) OR p.sku = $%[2]d OR EXISTS(SELECT 1 FROM product_barcodes b WHERE b.product_id = p.id AND b.code = $%[2]d)
+			OR EXISTS(SELECT 1 FROM product v WHERE v.parent_id = p.id AND (v.sku = $%[2]d OR EXISTS(SELECT 1 FROM product_barcodes b WHERE b.product_id = v.id AND b.code = $%[2]d))))`,
+			argsIdx,
+			argsIdx+1,
+		)
+		args = append(args, "%"+dto.Name+"%", dto.Name)
+		argsIdx += 2
 	}
 
 	query := fmt.Sprintf(`SELECT 
 		p.id,
 		p.name, 
+		p.sku,
 		p.price, 
+		p.cost,
 		p.stock, 
+		p.reorder_point,
+		p.reorder_quantity,
 		c.name as category_name,
 		p.created_at, 
-		p.updated_at 
+		p.updated_at,
+		p.deleted_at,
+		p.parent_id,
+		p.has_variants
 	FROM product p
 	JOIN categories c ON p.category_id = c.id
 	%s
-	ORDER BY created_at DESC
+	ORDER BY p.created_at DESC
 	LIMIT $%d OFFSET $%d`, whereClause.String(), argsIdx, argsIdx+1)
 	args = append(args, dto.Limit, dto.Offset)
 
@@ -77,11 +108,18 @@ func (p *productRepo) GetProduct(dto *dto.ProductQuery) ([]*model.ProductCategor
 		err := rows.Scan(
 			&product.ID,
 			&product.Name,
+			&product.SKU,
 			&product.Price,
+			&product.Cost,
 			&product.Stock,
+			&product.ReorderPoint,
+			&product.ReorderQuantity,
 			&product.CategoryName,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.DeletedAt,
+			&product.ParentID,
+			&product.HasVariants,
 		)
 
 		if err != nil {
@@ -95,6 +133,14 @@ func (p *productRepo) GetProduct(dto *dto.ProductQuery) ([]*model.ProductCategor
 		return nil, 0, rows.Err()
 	}
 
+	if err := p.attachBarcodes(listProduct); err != nil {
+		return nil, 0, err
+	}
+
+	if err := p.attachVariants(listProduct, dto.IncludeArchived); err != nil {
+		return nil, 0, err
+	}
+
 	var total int
 	err = p.db.QueryRow(fmt.Sprintf(`
 		SELECT COUNT(*) FROM product p
@@ -108,9 +154,16 @@ func (p *productRepo) GetProduct(dto *dto.ProductQuery) ([]*model.ProductCategor
 	return listProduct, total, nil
 }
 
-func (p *productRepo) CreateProduct(body *model.Product) (*model.Product, error) {
+// CreateProduct menyimpan produk dengan stok 0, stok awal dicatat sebagai movement adjustment
+func (p *productRepo) CreateProduct(body *model.Product, userID *uuid.UUID) (*model.Product, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	var exists bool
-	err := p.db.QueryRow("SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1)", body.CategoryID).Scan(&exists)
+	err = tx.QueryRow("SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1 AND deleted_at IS NULL)", body.CategoryID).Scan(&exists)
 	if err != nil {
 		return nil, err
 	}
@@ -119,13 +172,33 @@ func (p *productRepo) CreateProduct(body *model.Product) (*model.Product, error)
 		return nil, utils.ErrCategoryNotFound
 	}
 
-	rows := p.db.QueryRow(
-		`INSERT INTO product(id,name,price,stock,category_id, created_at, updated_at) VALUES($1,$2,$3,$4,$5, NOW(), NOW()) RETURNING id, created_at, updated_at`,
+	product, err := insertProduct(tx, p.dialect, body, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// insertProduct dipakai bersama oleh produk biasa dan varian. Produk disimpan dengan stok 0
+// lalu stok awal dicatat lewat applyStockMovement supaya tetap ada di ledger
+func insertProduct(tx *sql.Tx, dialect database.Dialect, body *model.Product, userID *uuid.UUID) (*model.Product, error) {
+	rows := tx.QueryRow(
+		fmt.Sprintf(`INSERT INTO product(id,name,sku,price,cost,stock,reorder_point,reorder_quantity,category_id,parent_id,variant_name, created_at, updated_at) VALUES($1,$2,$3,$4,$5,0,NULLIF($6, 0),$7,$8,$9,$10, %[1]s, %[1]s) RETURNING id, reorder_point, created_at, updated_at`, dialect.Now()),
 		body.ID,
 		body.Name,
+		body.SKU,
 		body.Price,
-		body.Stock,
+		body.Cost,
+		body.ReorderPoint,
+		body.ReorderQuantity,
 		body.CategoryID,
+		body.ParentID,
+		variantName(body.Options),
 	)
 
 	if rows.Err() != nil {
@@ -135,16 +208,46 @@ func (p *productRepo) CreateProduct(body *model.Product) (*model.Product, error)
 	var product model.Product
 	if err := rows.Scan(
 		&product.ID,
+		&product.ReorderPoint,
 		&product.CreatedAt,
 		&product.UpdatedAt,
 	); err != nil {
 		return nil, err
 	}
 
+	if err := replaceBarcodes(tx, dialect, body.ID, body.Barcodes); err != nil {
+		return nil, err
+	}
+	product.Barcodes = append(make([]string, 0), body.Barcodes...)
+
+	if len(body.Options) > 0 {
+		if err := replaceVariantOptions(tx, body.ID, body.Options); err != nil {
+			return nil, err
+		}
+	}
+
+	if body.Stock > 0 {
+		movement := &model.StockMovement{
+			ProductID: body.ID,
+			Type:      model.StockMovementAdjustment,
+			Quantity:  body.Stock,
+			Reason:    "stok awal",
+			CreatedBy: userID,
+		}
+		if err := applyStockMovement(tx, dialect, movement); err != nil {
+			return nil, err
+		}
+		product.Stock = movement.StockAfter
+	}
+
 	product.Name = body.Name
+	product.SKU = body.SKU
 	product.Price = body.Price
-	product.Stock = body.Stock
+	product.Cost = body.Cost
 	product.CategoryID = body.CategoryID
+	product.ReorderQuantity = body.ReorderQuantity
+	product.ParentID = body.ParentID
+	product.Options = body.Options
 
 	return &product, nil
 }
@@ -153,11 +256,18 @@ func (p *productRepo) GetProductByID(id string) (*model.ProductCategory, error)
 	query := `SELECT 
 		p.id,
 		p.name, 
+		p.sku,
 		p.price, 
+		p.cost,
 		p.stock, 
+		p.reorder_point,
+		p.reorder_quantity,
 		c.name as category_name,
 		p.created_at, 
-		p.updated_at 
+		p.updated_at,
+		p.deleted_at,
+		p.parent_id,
+		p.has_variants
 	FROM product p
 	JOIN categories c ON p.category_id = c.id
 	WHERE p.id = $1 
@@ -173,36 +283,333 @@ func (p *productRepo) GetProductByID(id string) (*model.ProductCategory, error)
 	err := rows.Scan(
 		&product.ID,
 		&product.Name,
+		&product.SKU,
 		&product.Price,
+		&product.Cost,
 		&product.Stock,
+		&product.ReorderPoint,
+		&product.ReorderQuantity,
 		&product.CategoryName,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.DeletedAt,
+		&product.ParentID,
+		&product.HasVariants,
 	)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	if err := p.attachBarcodes([]*model.ProductCategory{&product}); err != nil {
+		return nil, err
+	}
+
+	if err := p.attachOptions([]*model.ProductCategory{&product}); err != nil {
+		return nil, err
+	}
+
+	// varian yang ikut diarsipkan tetap ditampilkan saat melihat produk induk yang diarsipkan
+	if err := p.attachVariants([]*model.ProductCategory{&product}, product.DeletedAt != nil); err != nil {
+		return nil, err
+	}
+
+	if err := p.attachModifierGroups(&product); err != nil {
 		return nil, err
 	}
 
 	return &product, nil
 }
 
+// GetProductByBarcode dipakai saat scan di kasir, produk dicari lewat primary key product_barcodes
+// lalu dilengkapi sama seperti GetProductByID. Produk yang diarsipkan tidak ditemukan
+func (p *productRepo) GetProductByBarcode(code string) (*model.ProductCategory, error) {
+	var product model.ProductCategory
+	err := p.db.QueryRow(`SELECT
+		p.id,
+		p.name,
+		p.sku,
+		p.price,
+		p.cost,
+		p.stock,
+		p.reorder_point,
+		p.reorder_quantity,
+		c.name as category_name,
+		p.created_at,
+		p.updated_at,
+		p.deleted_at,
+		p.parent_id,
+		p.has_variants
+	FROM product_barcodes b
+	JOIN product p ON p.id = b.product_id
+	JOIN categories c ON p.category_id = c.id
+	WHERE b.code = $1 AND p.deleted_at IS NULL`,
+		code,
+	).Scan(
+		&product.ID,
+		&product.Name,
+		&product.SKU,
+		&product.Price,
+		&product.Cost,
+		&product.Stock,
+		&product.ReorderPoint,
+		&product.ReorderQuantity,
+		&product.CategoryName,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.DeletedAt,
+		&product.ParentID,
+		&product.HasVariants,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrBarcodeNotFound
+		}
+		return nil, err
+	}
+
+	if err := p.attachBarcodes([]*model.ProductCategory{&product}); err != nil {
+		return nil, err
+	}
+
+	if err := p.attachOptions([]*model.ProductCategory{&product}); err != nil {
+		return nil, err
+	}
+
+	if err := p.attachVariants([]*model.ProductCategory{&product}, false); err != nil {
+		return nil, err
+	}
+
+	if err := p.attachModifierGroups(&product); err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// attachModifierGroups mengisi modifier group yang bisa dipilih kasir untuk produk ini
+func (p *productRepo) attachModifierGroups(product *model.ProductCategory) error {
+	groups, err := productModifierGroups(p.db, []uuid.UUID{product.ID})
+	if err != nil {
+		return err
+	}
+
+	product.ModifierGroups = groups[product.ID]
+	return nil
+}
+
+// attachBarcodes mengambil barcode untuk banyak produk sekaligus (hindari N+1 query)
+func (p *productRepo) attachBarcodes(products []*model.ProductCategory) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+
+	barcodes, err := productBarcodes(p.db, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		product.Barcodes = append(make([]string, 0), barcodes[product.ID]...)
+	}
+
+	return nil
+}
+
+func productBarcodes(q queryer, productIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	ids := make([]any, len(productIDs))
+	placeholders := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		ids[i] = id
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	rows, err := q.Query(fmt.Sprintf(`
+		SELECT product_id, code
+		FROM product_barcodes
+		WHERE product_id IN (%s)
+		ORDER BY created_at, code`, strings.Join(placeholders, ",")),
+		ids...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query product barcodes failed: %w", err)
+	}
+	defer rows.Close()
+
+	barcodes := make(map[uuid.UUID][]string)
+	for rows.Next() {
+		var productID uuid.UUID
+		var code string
+		if err := rows.Scan(&productID, &code); err != nil {
+			return nil, fmt.Errorf("scan product barcode failed: %w", err)
+		}
+		barcodes[productID] = append(barcodes[productID], code)
+	}
+
+	return barcodes, rows.Err()
+}
+
+// replaceBarcodes mengganti seluruh barcode produk dengan daftar yang baru
+func replaceBarcodes(tx *sql.Tx, dialect database.Dialect, productID uuid.UUID, codes []string) error {
+	if _, err := tx.Exec(`DELETE FROM product_barcodes WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("delete product barcodes failed: %w", err)
+	}
+
+	for _, code := range codes {
+		_, err := tx.Exec(
+			fmt.Sprintf(`INSERT INTO product_barcodes (code, product_id, created_at) VALUES ($1,$2,%s)`, dialect.Now()),
+			code,
+			productID,
+		)
+		if err != nil {
+			return fmt.Errorf("insert product barcode failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteProductByID mengarsipkan produk. Baris produk tetap ada supaya riwayat
+// transaksi, movement dan PO tetap utuh. Produk induk diarsipkan bersama varian
+// aktifnya dalam satu statement supaya deleted_at-nya sama
 func (p *productRepo) DeleteProductByID(id string) error {
-	_, err := p.db.Exec(
-		`DELETE FROM product WHERE id = $1`,
+	result, err := p.db.Exec(
+		fmt.Sprintf(`UPDATE product SET deleted_at = %[1]s, updated_at = %[1]s WHERE (id = $1 OR parent_id = $1) AND deleted_at IS NULL`, p.dialect.Now()),
+		id,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ensureActiveProduct(p.db, id)
+	}
+
+	return nil
+}
+
+// RestoreProductByID mengaktifkan kembali produk yang diarsipkan, kategorinya harus aktif
+// lebih dulu. Varian butuh produk induk yang aktif, sedangkan produk induk ikut mengaktifkan
+// varian yang diarsipkan bersamanya (deleted_at sama), bukan yang sudah diarsipkan sebelumnya
+func (p *productRepo) RestoreProductByID(id string) (*model.ProductCategory, error) {
+	var categoryActive, parentActive bool
+	err := p.db.QueryRow(
+		`SELECT c.deleted_at IS NULL, COALESCE(parent.deleted_at IS NULL, TRUE)
+		FROM product p
+		JOIN categories c ON c.id = p.category_id
+		LEFT JOIN product parent ON parent.id = p.parent_id
+		WHERE p.id = $1`,
+		id,
+	).Scan(&categoryActive, &parentActive)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	if !categoryActive {
+		return nil, utils.ErrCategoryArchived
+	}
+
+	if !parentActive {
+		return nil, fmt.Errorf("%w: restore the parent product first", utils.ErrProductArchived)
+	}
+
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`UPDATE product SET deleted_at = NULL, updated_at = %s WHERE parent_id = $1 AND deleted_at = (SELECT deleted_at FROM product WHERE id = $1)`, p.dialect.Now()),
 		id,
 	)
+	if err != nil {
+		return nil, err
+	}
 
+	_, err = tx.Exec(
+		fmt.Sprintf(`UPDATE product SET deleted_at = NULL, updated_at = %s WHERE id = $1 AND deleted_at IS NOT NULL`, p.dialect.Now()),
+		id,
+	)
 	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return p.GetProductByID(id)
+}
+
+// ensureActiveProduct mengembalikan ErrProductNotFound jika produk tidak ada
+// dan ErrProductArchived jika produk sudah diarsipkan
+func ensureActiveProduct(q queryRower, id any) error {
+	var archived bool
+	err := q.QueryRow("SELECT deleted_at IS NOT NULL FROM product WHERE id = $1", id).Scan(&archived)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.ErrProductNotFound
+		}
 		return err
 	}
 
+	if archived {
+		return utils.ErrProductArchived
+	}
+
+	return nil
+}
+
+// ensureSellableProduct seperti ensureActiveProduct, tapi juga menolak produk induk varian
+// karena stok dan penjualannya ada di masing-masing varian
+func ensureSellableProduct(q queryRower, id any) error {
+	if err := ensureActiveProduct(q, id); err != nil {
+		return err
+	}
+
+	var hasVariants bool
+	if err := q.QueryRow("SELECT has_variants FROM product WHERE id = $1", id).Scan(&hasVariants); err != nil {
+		return err
+	}
+
+	if hasVariants {
+		return utils.ErrProductHasVariants
+	}
+
 	return nil
 }
 
-func (p *productRepo) UpdateProductByID(id string, body *model.Product) (*model.Product, error) {
+// UpdateProductByID tidak menimpa stok langsung. Jika stock diisi, selisih dengan stok
+// sekarang dicatat sebagai movement adjustment dalam transaksi yang sama.
+// cost kosong berarti HPP tidak diubah, begitu juga barcode jika body.Barcodes nil.
+// Nama dan kategori produk induk ikut diterapkan ke semua variannya
+func (p *productRepo) UpdateProductByID(id string, body *model.Product, stock *int, cost *int64, userID *uuid.UUID) (*model.Product, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	var exists bool
-	err := p.db.QueryRow("SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1)", body.CategoryID).Scan(&exists)
+	err = tx.QueryRow("SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1 AND deleted_at IS NULL)", body.CategoryID).Scan(&exists)
 	if err != nil {
 		return nil, err
 	}
@@ -211,12 +618,56 @@ func (p *productRepo) UpdateProductByID(id string, body *model.Product) (*model.
 		return nil, utils.ErrCategoryNotFound
 	}
 
-	rows := p.db.QueryRow(
-		`UPDATE product SET name = $1, price = $2, stock = $3, category_id = $4, updated_at = NOW() WHERE id = $5 RETURNING id, created_at, updated_at`,
+	var isVariant bool
+	err = tx.QueryRow("SELECT parent_id IS NOT NULL FROM product WHERE id = $1", id).Scan(&isVariant)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	if isVariant {
+		return nil, utils.ErrProductIsVariant
+	}
+
+	product, err := updateProduct(tx, p.dialect, id, body, stock, cost, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.HasVariants {
+		_, err := tx.Exec(
+			fmt.Sprintf(`UPDATE product SET name = CAST($1 AS TEXT) || ' - ' || variant_name, category_id = $2, updated_at = %s WHERE parent_id = $3`, p.dialect.Now()),
+			body.Name,
+			body.CategoryID,
+			product.ID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("update product variants failed: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// updateProduct dipakai bersama oleh produk biasa dan varian. Opsi varian hanya diganti
+// jika body.Options tidak nil, reorder point nil dipertahankan dan 0 berarti berhenti dipantau
+func updateProduct(tx *sql.Tx, dialect database.Dialect, id string, body *model.Product, stock *int, cost *int64, userID *uuid.UUID) (*model.Product, error) {
+	rows := tx.QueryRow(
+		fmt.Sprintf(`UPDATE product SET name = $1, sku = $2, price = $3, category_id = $4, reorder_point = CASE WHEN $5 IS NULL THEN reorder_point ELSE NULLIF($5, 0) END, reorder_quantity = $6, cost = COALESCE($7, cost), variant_name = COALESCE($8, variant_name), updated_at = %s WHERE id = $9 AND deleted_at IS NULL RETURNING id, cost, stock, reorder_point, parent_id, has_variants, created_at, updated_at`, dialect.Now()),
 		body.Name,
+		body.SKU,
 		body.Price,
-		body.Stock,
 		body.CategoryID,
+		body.ReorderPoint,
+		body.ReorderQuantity,
+		cost,
+		variantName(body.Options),
 		id,
 	)
 
@@ -227,16 +678,197 @@ func (p *productRepo) UpdateProductByID(id string, body *model.Product) (*model.
 	var product model.Product
 	if err := rows.Scan(
 		&product.ID,
+		&product.Cost,
+		&product.Stock,
+		&product.ReorderPoint,
+		&product.ParentID,
+		&product.HasVariants,
 		&product.CreatedAt,
 		&product.UpdatedAt,
 	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if err := ensureActiveProduct(tx, id); err != nil {
+				return nil, err
+			}
+			return nil, utils.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	if body.Barcodes != nil {
+		if err := replaceBarcodes(tx, dialect, product.ID, body.Barcodes); err != nil {
+			return nil, err
+		}
+	}
+
+	barcodes, err := productBarcodes(tx, []uuid.UUID{product.ID})
+	if err != nil {
 		return nil, err
 	}
+	product.Barcodes = append(make([]string, 0), barcodes[product.ID]...)
+
+	if body.Options != nil {
+		if err := replaceVariantOptions(tx, product.ID, body.Options); err != nil {
+			return nil, err
+		}
+	}
+	product.Options = body.Options
+
+	if stock != nil && *stock != product.Stock {
+		movement := &model.StockMovement{
+			ProductID: product.ID,
+			Type:      model.StockMovementAdjustment,
+			Quantity:  *stock - product.Stock,
+			Reason:    "koreksi stok dari update produk",
+			CreatedBy: userID,
+		}
+		if err := applyStockMovement(tx, dialect, movement); err != nil {
+			return nil, err
+		}
+		product.Stock = movement.StockAfter
+	}
 
 	product.Name = body.Name
+	product.SKU = body.SKU
 	product.Price = body.Price
-	product.Stock = body.Stock
 	product.CategoryID = body.CategoryID
+	product.ReorderQuantity = body.ReorderQuantity
 
 	return &product, nil
 }
+
+// GetLowStockProducts mengambil produk dengan stok sama atau di bawah reorder point,
+// yang paling jauh di bawah reorder point ditampilkan lebih dulu
+func (p *productRepo) GetLowStockProducts(query *dto.LowStockQuery) ([]*model.LowStockProduct, int, error) {
+	var whereClause strings.Builder
+	var args []any
+	argsIdx := 1
+
+	whereClause.WriteString("WHERE p.deleted_at IS NULL AND p.reorder_point IS NOT NULL AND p.stock <= p.reorder_point ")
+
+	if query.CategoryID != "" {
+		fmt.Fprintf(&whereClause, " AND p.category_id = $%d", argsIdx)
+		args = append(args, query.CategoryID)
+		argsIdx++
+	}
+
+	rows, err := p.db.Query(fmt.Sprintf(`
+		SELECT
+			p.id,
+			p.name,
+			c.name,
+			p.stock,
+			p.reorder_point,
+			p.reorder_quantity
+		FROM product p
+		JOIN categories c ON p.category_id = c.id
+		%s
+		ORDER BY p.stock - p.reorder_point ASC, p.name ASC
+		LIMIT $%d OFFSET $%d`, whereClause.String(), argsIdx, argsIdx+1),
+		append(args, query.Limit, query.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	products := make([]*model.LowStockProduct, 0)
+	for rows.Next() {
+		var product model.LowStockProduct
+		if err := rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.CategoryName,
+			&product.Stock,
+			&product.ReorderPoint,
+			&product.ReorderQuantity,
+		); err != nil {
+			return nil, 0, err
+		}
+		products = append(products, &product)
+	}
+
+	if rows.Err() != nil {
+		return nil, 0, rows.Err()
+	}
+
+	var total int
+	err = p.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM product p %s`, whereClause.String()),
+		args...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return products, total, nil
+}
+
+// GetLabelProducts mengambil produk aktif untuk cetak label beserta barcodenya,
+// diurutkan per nama supaya mudah ditempel per rak. Produk induk diganti dengan variannya
+func (p *productRepo) GetLabelProducts(query *dto.LabelRequest) ([]*model.ProductCategory, error) {
+	var whereClause strings.Builder
+	var args []any
+	argsIdx := 1
+
+	whereClause.WriteString("WHERE p.deleted_at IS NULL AND p.has_variants = FALSE ")
+
+	if query.CategoryID != "" {
+		fmt.Fprintf(&whereClause, " AND p.category_id = $%d", argsIdx)
+		args = append(args, query.CategoryID)
+		argsIdx++
+	}
+
+	if len(query.ProductIDs) > 0 {
+		placeholders := make([]string, len(query.ProductIDs))
+		for i, id := range query.ProductIDs {
+			placeholders[i] = fmt.Sprintf("$%d", argsIdx)
+			args = append(args, id)
+			argsIdx++
+		}
+		fmt.Fprintf(&whereClause, " AND (p.id IN (%[1]s) OR p.parent_id IN (%[1]s))", strings.Join(placeholders, ","))
+	}
+
+	rows, err := p.db.Query(fmt.Sprintf(`
+		SELECT
+			p.id,
+			p.name,
+			p.sku,
+			p.price,
+			c.name
+		FROM product p
+		JOIN categories c ON p.category_id = c.id
+		%s
+		ORDER BY p.name`, whereClause.String()),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]*model.ProductCategory, 0)
+	for rows.Next() {
+		var product model.ProductCategory
+		if err := rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.SKU,
+			&product.Price,
+			&product.CategoryName,
+		); err != nil {
+			return nil, err
+		}
+		products = append(products, &product)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	if err := p.attachBarcodes(products); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
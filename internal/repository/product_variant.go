@@ -0,0 +1,372 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/gofrs/uuid/v5"
+)
+
+// CreateVariant menambah varian ke produk induk. Produk biasa otomatis menjadi produk induk
+// saat varian pertamanya dibuat, asalkan stoknya sudah 0 karena stok hanya disimpan di varian.
+// Nama varian dibentuk dari nama induk dan nilai opsi, kategorinya mengikuti induk
+func (p *productRepo) CreateVariant(parentID string, body *model.Product, userID *uuid.UUID) (*model.Product, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	parent, err := lockVariantParent(tx, p.dialect, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !parent.HasVariants {
+		if parent.ParentID != nil {
+			return nil, fmt.Errorf("%w: %s is a variant", utils.ErrInvalidVariantParent, parent.ID)
+		}
+
+		if parent.Stock != 0 {
+			return nil, fmt.Errorf("%w: %s still has stock %d, adjust it to 0 first", utils.ErrInvalidVariantParent, parent.ID, parent.Stock)
+		}
+
+		_, err := tx.Exec(
+			fmt.Sprintf(`UPDATE product SET has_variants = TRUE, updated_at = %s WHERE id = $1`, p.dialect.Now()),
+			parent.ID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("mark product as variant parent failed: %w", err)
+		}
+	}
+
+	if err := checkVariantOptions(tx, parent.ID, body.ID, body.Options); err != nil {
+		return nil, err
+	}
+
+	body.ParentID = &parent.ID
+	body.CategoryID = parent.CategoryID
+	body.Name = parent.Name + " - " + *variantName(body.Options)
+
+	product, err := insertProduct(tx, p.dialect, body, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// UpdateVariant mengubah opsi, SKU, barcode, harga dan stok varian. Aturan stok, cost dan
+// barcode sama dengan UpdateProductByID
+func (p *productRepo) UpdateVariant(parentID, variantID string, body *model.Product, stock *int, cost *int64, userID *uuid.UUID) (*model.Product, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	parent, err := lockVariantParent(tx, p.dialect, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var variantParentID *uuid.UUID
+	err = tx.QueryRow("SELECT parent_id FROM product WHERE id = $1", variantID).Scan(&variantParentID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if variantParentID == nil || *variantParentID != parent.ID {
+		return nil, fmt.Errorf("%w: %s", utils.ErrProductNotFound, variantID)
+	}
+
+	if err := checkVariantOptions(tx, parent.ID, variantID, body.Options); err != nil {
+		return nil, err
+	}
+
+	body.CategoryID = parent.CategoryID
+	body.Name = parent.Name + " - " + *variantName(body.Options)
+
+	product, err := updateProduct(tx, p.dialect, variantID, body, stock, cost, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// lockVariantParent mengunci produk induk supaya pengecekan opsi antar varian tidak balapan
+func lockVariantParent(tx *sql.Tx, dialect database.Dialect, id string) (*model.Product, error) {
+	var parent model.Product
+	err := tx.QueryRow(
+		fmt.Sprintf(`SELECT id, name, category_id, stock, parent_id, has_variants, deleted_at FROM product WHERE id = $1 %s`, dialect.ForUpdate()),
+		id,
+	).Scan(
+		&parent.ID,
+		&parent.Name,
+		&parent.CategoryID,
+		&parent.Stock,
+		&parent.ParentID,
+		&parent.HasVariants,
+		&parent.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	if parent.DeletedAt != nil {
+		return nil, utils.ErrProductArchived
+	}
+
+	return &parent, nil
+}
+
+// checkVariantOptions memastikan nama opsi sama (dan berurutan sama) dengan varian lain
+// dan kombinasi nilainya belum dipakai varian lain, termasuk yang diarsipkan
+func checkVariantOptions(q queryer, parentID uuid.UUID, excludeID any, options []model.VariantOption) error {
+	rows, err := q.Query(
+		`SELECT o.name
+		FROM product_variant_options o
+		WHERE o.product_id = (
+			SELECT id FROM product WHERE parent_id = $1 AND id <> $2 ORDER BY created_at LIMIT 1
+		)
+		ORDER BY o.position`,
+		parentID,
+		excludeID,
+	)
+	if err != nil {
+		return fmt.Errorf("query variant options failed: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return fmt.Errorf("scan variant option failed: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if names != nil {
+		if len(names) != len(options) {
+			return fmt.Errorf("%w: expected %s", utils.ErrInvalidVariantOptions, strings.Join(names, ", "))
+		}
+
+		for i, name := range names {
+			if !strings.EqualFold(name, options[i].Name) {
+				return fmt.Errorf("%w: expected %s", utils.ErrInvalidVariantOptions, strings.Join(names, ", "))
+			}
+		}
+	}
+
+	var exists bool
+	err = q.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM product WHERE parent_id = $1 AND id <> $2 AND LOWER(variant_name) = LOWER($3))`,
+		parentID,
+		excludeID,
+		variantName(options),
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return fmt.Errorf("%w: %s", utils.ErrVariantExists, *variantName(options))
+	}
+
+	return nil
+}
+
+// variantName menggabungkan nilai opsi, misalnya "L / Merah". Nil untuk produk tanpa opsi
+func variantName(options []model.VariantOption) *string {
+	if len(options) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(options))
+	for i, option := range options {
+		values[i] = option.Value
+	}
+
+	name := strings.Join(values, " / ")
+	return &name
+}
+
+// replaceVariantOptions mengganti seluruh opsi varian, urutannya disimpan di kolom position
+func replaceVariantOptions(tx *sql.Tx, productID uuid.UUID, options []model.VariantOption) error {
+	if _, err := tx.Exec(`DELETE FROM product_variant_options WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("delete variant options failed: %w", err)
+	}
+
+	for i, option := range options {
+		_, err := tx.Exec(
+			`INSERT INTO product_variant_options (product_id, position, name, value) VALUES ($1,$2,$3,$4)`,
+			productID,
+			i+1,
+			option.Name,
+			option.Value,
+		)
+		if err != nil {
+			return fmt.Errorf("insert variant option failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// attachVariants mengisi Variants untuk produk induk dalam satu query, lengkap dengan
+// opsi dan barcode masing-masing varian
+func (p *productRepo) attachVariants(products []*model.ProductCategory, includeArchived bool) error {
+	parents := make(map[uuid.UUID]*model.ProductCategory)
+	var args []any
+	var placeholders []string
+	for _, product := range products {
+		if !product.HasVariants {
+			continue
+		}
+		parents[product.ID] = product
+		args = append(args, product.ID)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	if len(parents) == 0 {
+		return nil
+	}
+
+	archivedFilter := " AND p.deleted_at IS NULL"
+	if includeArchived {
+		archivedFilter = ""
+	}
+
+	rows, err := p.db.Query(fmt.Sprintf(`SELECT
+		p.id,
+		p.name,
+		p.sku,
+		p.price,
+		p.cost,
+		p.stock,
+		p.reorder_point,
+		p.reorder_quantity,
+		c.name as category_name,
+		p.created_at,
+		p.updated_at,
+		p.deleted_at,
+		p.parent_id,
+		p.has_variants
+	FROM product p
+	JOIN categories c ON p.category_id = c.id
+	WHERE p.parent_id IN (%s)%s
+	ORDER BY p.created_at`, strings.Join(placeholders, ","), archivedFilter),
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("query product variants failed: %w", err)
+	}
+	defer rows.Close()
+
+	variants := make([]*model.ProductCategory, 0)
+	for rows.Next() {
+		var variant model.ProductCategory
+		if err := rows.Scan(
+			&variant.ID,
+			&variant.Name,
+			&variant.SKU,
+			&variant.Price,
+			&variant.Cost,
+			&variant.Stock,
+			&variant.ReorderPoint,
+			&variant.ReorderQuantity,
+			&variant.CategoryName,
+			&variant.CreatedAt,
+			&variant.UpdatedAt,
+			&variant.DeletedAt,
+			&variant.ParentID,
+			&variant.HasVariants,
+		); err != nil {
+			return fmt.Errorf("scan product variant failed: %w", err)
+		}
+		variants = append(variants, &variant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if err := p.attachBarcodes(variants); err != nil {
+		return err
+	}
+
+	if err := p.attachOptions(variants); err != nil {
+		return err
+	}
+
+	for _, variant := range variants {
+		parent := parents[*variant.ParentID]
+		parent.Variants = append(parent.Variants, variant)
+	}
+
+	return nil
+}
+
+// attachOptions mengisi Options untuk produk yang merupakan varian
+func (p *productRepo) attachOptions(products []*model.ProductCategory) error {
+	variants := make(map[uuid.UUID]*model.ProductCategory)
+	var args []any
+	var placeholders []string
+	for _, product := range products {
+		if product.ParentID == nil {
+			continue
+		}
+		variants[product.ID] = product
+		args = append(args, product.ID)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	if len(variants) == 0 {
+		return nil
+	}
+
+	rows, err := p.db.Query(fmt.Sprintf(`
+		SELECT product_id, name, value
+		FROM product_variant_options
+		WHERE product_id IN (%s)
+		ORDER BY position`, strings.Join(placeholders, ",")),
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("query variant options failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID uuid.UUID
+		var option model.VariantOption
+		if err := rows.Scan(&productID, &option.Name, &option.Value); err != nil {
+			return fmt.Errorf("scan variant option failed: %w", err)
+		}
+		variants[productID].Options = append(variants[productID].Options, option)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,406 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/gofrs/uuid/v5"
+)
+
+type PromotionRepository interface {
+	GetPromotions(query *dto.PromotionQuery) ([]*model.Promotion, int, error)
+	GetPromotionByID(id string) (*model.Promotion, error)
+	CreatePromotion(body *model.Promotion) (*model.Promotion, error)
+	UpdatePromotionByID(id string, body *model.Promotion) (*model.Promotion, error)
+	DeletePromotionByID(id string) error
+}
+
+type promotionRepo struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewPromotionRepository(db *sql.DB, dialect database.Dialect) PromotionRepository {
+	return &promotionRepo{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+const promotionColumns = `
+	id,
+	name,
+	type,
+	COALESCE(code, ''),
+	product_id,
+	value,
+	buy_quantity,
+	get_quantity,
+	bundle_quantity,
+	bundle_price,
+	min_purchase,
+	max_discount,
+	start_at,
+	end_at,
+	is_active,
+	created_at,
+	updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPromotion(row rowScanner) (*model.Promotion, error) {
+	var promotion model.Promotion
+	err := row.Scan(
+		&promotion.ID,
+		&promotion.Name,
+		&promotion.Type,
+		&promotion.Code,
+		&promotion.ProductID,
+		&promotion.Value,
+		&promotion.BuyQuantity,
+		&promotion.GetQuantity,
+		&promotion.BundleQuantity,
+		&promotion.BundlePrice,
+		&promotion.MinPurchase,
+		&promotion.MaxDiscount,
+		&promotion.StartAt,
+		&promotion.EndAt,
+		&promotion.IsActive,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func (p *promotionRepo) GetPromotions(query *dto.PromotionQuery) ([]*model.Promotion, int, error) {
+	var whereClause strings.Builder
+	var args []any
+	argsIdx := 1
+
+	whereClause.WriteString("WHERE 1=1 ")
+
+	if query.Name != "" {
+		fmt.Fprintf(&whereClause, " AND LOWER(name) LIKE LOWER($%d)", argsIdx)
+		args = append(args, "%"+query.Name+"%")
+		argsIdx++
+	}
+
+	if query.Type != "" {
+		fmt.Fprintf(&whereClause, " AND type = $%d", argsIdx)
+		args = append(args, query.Type)
+		argsIdx++
+	}
+
+	if query.IsActive != nil {
+		fmt.Fprintf(&whereClause, " AND is_active = $%d", argsIdx)
+		args = append(args, *query.IsActive)
+		argsIdx++
+	}
+
+	rows, err := p.db.Query(fmt.Sprintf(`
+		SELECT %s
+		FROM promotions
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, promotionColumns, whereClause.String(), argsIdx, argsIdx+1),
+		append(args, query.Limit, query.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	promotions := make([]*model.Promotion, 0)
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	if rows.Err() != nil {
+		return nil, 0, rows.Err()
+	}
+
+	var total int
+	err = p.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM promotions %s`, whereClause.String()),
+		args...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return promotions, total, nil
+}
+
+func (p *promotionRepo) GetPromotionByID(id string) (*model.Promotion, error) {
+	promotion, err := scanPromotion(p.db.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM promotions WHERE id = $1`, promotionColumns),
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrPromotionNotFound
+		}
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (p *promotionRepo) CreatePromotion(body *model.Promotion) (*model.Promotion, error) {
+	if err := p.ensureProductExists(body.ProductID); err != nil {
+		return nil, err
+	}
+
+	err := p.db.QueryRow(
+		fmt.Sprintf(`INSERT INTO promotions(
+			id, name, type, code, product_id, value, buy_quantity, get_quantity,
+			bundle_quantity, bundle_price, min_purchase, max_discount, start_at, end_at,
+			is_active, created_at, updated_at
+		) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,%[1]s,%[1]s)
+		RETURNING created_at, updated_at`, p.dialect.Now()),
+		body.ID,
+		body.Name,
+		body.Type,
+		nullString(body.Code),
+		body.ProductID,
+		body.Value,
+		body.BuyQuantity,
+		body.GetQuantity,
+		body.BundleQuantity,
+		body.BundlePrice,
+		body.MinPurchase,
+		body.MaxDiscount,
+		body.StartAt.UTC(),
+		body.EndAt.UTC(),
+		body.IsActive,
+	).Scan(&body.CreatedAt, &body.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+func (p *promotionRepo) UpdatePromotionByID(id string, body *model.Promotion) (*model.Promotion, error) {
+	if err := p.ensureProductExists(body.ProductID); err != nil {
+		return nil, err
+	}
+
+	err := p.db.QueryRow(
+		fmt.Sprintf(`UPDATE promotions SET
+			name = $1, type = $2, code = $3, product_id = $4, value = $5,
+			buy_quantity = $6, get_quantity = $7, bundle_quantity = $8, bundle_price = $9,
+			min_purchase = $10, max_discount = $11, start_at = $12, end_at = $13,
+			is_active = $14, updated_at = %s
+		WHERE id = $15
+		RETURNING id, created_at, updated_at`, p.dialect.Now()),
+		body.Name,
+		body.Type,
+		nullString(body.Code),
+		body.ProductID,
+		body.Value,
+		body.BuyQuantity,
+		body.GetQuantity,
+		body.BundleQuantity,
+		body.BundlePrice,
+		body.MinPurchase,
+		body.MaxDiscount,
+		body.StartAt.UTC(),
+		body.EndAt.UTC(),
+		body.IsActive,
+		id,
+	).Scan(&body.ID, &body.CreatedAt, &body.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrPromotionNotFound
+		}
+		return nil, err
+	}
+
+	return body, nil
+}
+
+func (p *promotionRepo) DeletePromotionByID(id string) error {
+	result, err := p.db.Exec(`DELETE FROM promotions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return utils.ErrPromotionNotFound
+	}
+
+	return nil
+}
+
+func (p *promotionRepo) ensureProductExists(productID *uuid.UUID) error {
+	if productID == nil {
+		return nil
+	}
+
+	return ensureSellableProduct(p.db, productID)
+}
+
+// findItemPromotions mengambil promo level item yang berlaku untuk produk di keranjang,
+// dikelompokkan per produk. Waktu dibandingkan dalam UTC supaya sama dengan yang tersimpan di sqlite
+func findItemPromotions(tx *sql.Tx, productIDs []uuid.UUID, at time.Time) (map[uuid.UUID][]*model.Promotion, error) {
+	result := make(map[uuid.UUID][]*model.Promotion)
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	args := []any{at.UTC()}
+	placeholders := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		args = append(args, id)
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+	}
+
+	rows, err := tx.Query(fmt.Sprintf(`
+		SELECT %s
+		FROM promotions
+		WHERE is_active = TRUE
+		  AND start_at <= $1 AND end_at > $1
+		  AND code IS NULL
+		  AND product_id IN (%s)`, promotionColumns, strings.Join(placeholders, ",")),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query promotions failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan promotion failed: %w", err)
+		}
+		result[*promotion.ProductID] = append(result[*promotion.ProductID], promotion)
+	}
+
+	return result, rows.Err()
+}
+
+// findPromotionByCode mengambil promo keranjang berdasarkan kode yang dimasukkan kasir
+func findPromotionByCode(tx *sql.Tx, code string, at time.Time) (*model.Promotion, error) {
+	promotion, err := scanPromotion(tx.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM promotions WHERE code = $1`, promotionColumns),
+		code,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: code %q not found", utils.ErrInvalidPromoCode, code)
+		}
+		return nil, err
+	}
+
+	if !promotion.IsCartLevel() || !promotion.IsValidAt(at) {
+		return nil, fmt.Errorf("%w: code %q is not valid at this time", utils.ErrInvalidPromoCode, code)
+	}
+
+	return promotion, nil
+}
+
+// bestItemPromotion memilih promo item dengan potongan terbesar, promo item tidak ditumpuk
+func bestItemPromotion(promotions []*model.Promotion, unitPrice int, quantity int) (*model.Promotion, int64) {
+	var best *model.Promotion
+	var bestDiscount int64
+
+	for _, promotion := range promotions {
+		discount := promotion.ItemDiscount(unitPrice, quantity)
+		if discount > bestDiscount {
+			best = promotion
+			bestDiscount = discount
+		}
+	}
+
+	return best, bestDiscount
+}
+
+// itemDiscount adalah promo item dan potongan yang jatuh ke satu baris checkout
+type itemDiscount struct {
+	promotion *model.Promotion
+	amount    int64
+}
+
+// groupItemDiscounts menghitung promo item per produk, bukan per baris, supaya baris produk
+// yang sama (misalnya beda modifier) digabung untuk buy X get Y dan bundle. Potongan produk
+// dibagi ke barisnya sebanding qty, sisa pembulatan masuk ke baris terakhir produk tersebut
+func groupItemDiscounts(items []dto.CheckoutItem, products []checkoutProduct, promotions map[uuid.UUID][]*model.Promotion) []itemDiscount {
+	quantities := make(map[uuid.UUID]int)
+	for _, item := range items {
+		quantities[item.ProductID] += item.Quantity
+	}
+
+	best := make(map[uuid.UUID]*model.Promotion)
+	remaining := make(map[uuid.UUID]int64)
+	discounts := make([]itemDiscount, len(items))
+	for i, item := range items {
+		id := item.ProductID
+		if _, ok := remaining[id]; !ok {
+			best[id], remaining[id] = bestItemPromotion(promotions[id], products[i].Price, quantities[id])
+		}
+
+		share := remaining[id] * int64(item.Quantity) / int64(quantities[id])
+		remaining[id] -= share
+		quantities[id] -= item.Quantity
+
+		if share > 0 {
+			discounts[i] = itemDiscount{promotion: best[id], amount: share}
+		}
+	}
+
+	return discounts
+}
+
+// allocateCartDiscount membagi potongan keranjang ke tiap detail secara proporsional
+// terhadap subtotal, sisa pembulatan dibebankan ke detail terakhir. Dengan begitu
+// jumlah subtotal detail selalu sama dengan total transaksi.
+func allocateCartDiscount(details []model.TransactionDetail, cartDiscount int64) {
+	var subtotal int64
+	for _, d := range details {
+		subtotal += d.Subtotal
+	}
+
+	if subtotal == 0 || cartDiscount == 0 {
+		return
+	}
+
+	remaining := cartDiscount
+	for i := range details {
+		share := cartDiscount * details[i].Subtotal / subtotal
+		if i == len(details)-1 {
+			share = remaining
+		}
+
+		details[i].Discount += share
+		details[i].Subtotal -= share
+		remaining -= share
+	}
+}
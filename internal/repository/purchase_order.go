@@ -0,0 +1,603 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/gofrs/uuid/v5"
+)
+
+type PurchaseOrderRepository interface {
+	CreatePurchaseOrder(order *model.PurchaseOrder) (*model.PurchaseOrder, error)
+	GetPurchaseOrders(query *dto.PurchaseOrderQuery) ([]*model.PurchaseOrder, int, error)
+	GetPurchaseOrderByID(id string) (*model.PurchaseOrder, error)
+	UpdatePurchaseOrderByID(id string, order *model.PurchaseOrder) (*model.PurchaseOrder, error)
+	SendPurchaseOrder(id string) (*model.PurchaseOrder, error)
+	ReceivePurchaseOrder(id string, receipt *model.GoodsReceipt) (*model.PurchaseOrder, error)
+	ClosePurchaseOrder(id string) (*model.PurchaseOrder, error)
+}
+
+type purchaseOrderRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewPurchaseOrderRepository(db *sql.DB, dialect database.Dialect) PurchaseOrderRepository {
+	return &purchaseOrderRepository{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// purchaseOrderColumns dipakai bersama JOIN suppliers s untuk nama supplier
+const purchaseOrderColumns = `
+	po.id,
+	po.supplier_id,
+	s.name,
+	po.status,
+	COALESCE(po.note, ''),
+	po.total_cost,
+	po.created_by,
+	po.created_at,
+	po.updated_at,
+	po.sent_at,
+	po.closed_at`
+
+func scanPurchaseOrder(row rowScanner) (*model.PurchaseOrder, error) {
+	var order model.PurchaseOrder
+	err := row.Scan(
+		&order.ID,
+		&order.SupplierID,
+		&order.SupplierName,
+		&order.Status,
+		&order.Note,
+		&order.TotalCost,
+		&order.CreatedBy,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.SentAt,
+		&order.ClosedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// getPurchaseOrder mengambil PO beserta item dan riwayat penerimaannya
+func getPurchaseOrder(q queryer, id string) (*model.PurchaseOrder, error) {
+	order, err := scanPurchaseOrder(q.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM purchase_orders po JOIN suppliers s ON s.id = po.supplier_id WHERE po.id = $1`, purchaseOrderColumns),
+		id,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Items, err = purchaseOrderItems(q, order.ID); err != nil {
+		return nil, err
+	}
+
+	if order.Receipts, err = goodsReceipts(q, order.ID); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func purchaseOrderItems(q queryer, orderID uuid.UUID) ([]model.PurchaseOrderItem, error) {
+	rows, err := q.Query(`
+		SELECT i.id, i.product_id, p.name, i.quantity, i.received_quantity, i.unit_cost
+		FROM purchase_order_items i
+		JOIN product p ON p.id = i.product_id
+		WHERE i.purchase_order_id = $1
+		ORDER BY p.name`,
+		orderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]model.PurchaseOrderItem, 0)
+	for rows.Next() {
+		var item model.PurchaseOrderItem
+		if err := rows.Scan(
+			&item.ID,
+			&item.ProductID,
+			&item.ProductName,
+			&item.Quantity,
+			&item.ReceivedQuantity,
+			&item.UnitCost,
+		); err != nil {
+			return nil, err
+		}
+		item.Subtotal = int64(item.Quantity) * item.UnitCost
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func goodsReceipts(q queryer, orderID uuid.UUID) ([]model.GoodsReceipt, error) {
+	rows, err := q.Query(`
+		SELECT
+			r.id,
+			r.purchase_order_id,
+			COALESCE(r.note, ''),
+			r.received_by,
+			COALESCE(u.name, ''),
+			r.created_at,
+			ri.product_id,
+			p.name,
+			ri.quantity,
+			ri.unit_cost
+		FROM goods_receipts r
+		JOIN goods_receipt_items ri ON ri.receipt_id = r.id
+		JOIN product p ON p.id = ri.product_id
+		LEFT JOIN users u ON u.id = r.received_by
+		WHERE r.purchase_order_id = $1
+		ORDER BY r.created_at, r.id, p.name`,
+		orderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := make([]model.GoodsReceipt, 0)
+	for rows.Next() {
+		var receipt model.GoodsReceipt
+		var item model.GoodsReceiptItem
+		if err := rows.Scan(
+			&receipt.ID,
+			&receipt.PurchaseOrderID,
+			&receipt.Note,
+			&receipt.ReceivedBy,
+			&receipt.ReceivedByName,
+			&receipt.CreatedAt,
+			&item.ProductID,
+			&item.ProductName,
+			&item.Quantity,
+			&item.UnitCost,
+		); err != nil {
+			return nil, err
+		}
+
+		if n := len(receipts); n > 0 && receipts[n-1].ID == receipt.ID {
+			receipts[n-1].Items = append(receipts[n-1].Items, item)
+			continue
+		}
+		receipt.Items = []model.GoodsReceiptItem{item}
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, rows.Err()
+}
+
+// lockPurchaseOrder mengunci PO dan memastikan statusnya salah satu dari allowed
+func (r *purchaseOrderRepository) lockPurchaseOrder(tx *sql.Tx, id string, allowed ...string) error {
+	var status string
+	err := tx.QueryRow(
+		fmt.Sprintf(`SELECT status FROM purchase_orders WHERE id = $1 %s`, r.dialect.ForUpdate()),
+		id,
+	).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.ErrPurchaseOrderNotFound
+		}
+		return err
+	}
+
+	if slices.Contains(allowed, status) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: purchase order is %s", utils.ErrPurchaseOrderStatus, status)
+}
+
+// insertPurchaseOrderItems memastikan supplier ada dan semua produk aktif sebelum item disimpan
+func insertPurchaseOrderItems(tx *sql.Tx, order *model.PurchaseOrder) error {
+	var exists bool
+	err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM suppliers WHERE id = $1)", order.SupplierID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return utils.ErrSupplierNotFound
+	}
+
+	for _, item := range order.Items {
+		if err := ensureSellableProduct(tx, item.ProductID); err != nil {
+			return fmt.Errorf("%w: %s", err, item.ProductID)
+		}
+
+		_, err := tx.Exec(
+			`INSERT INTO purchase_order_items (id, purchase_order_id, product_id, quantity, received_quantity, unit_cost)
+			VALUES ($1,$2,$3,$4,0,$5)`,
+			item.ID,
+			order.ID,
+			item.ProductID,
+			item.Quantity,
+			item.UnitCost,
+		)
+		if err != nil {
+			return fmt.Errorf("insert purchase order item failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *purchaseOrderRepository) CreatePurchaseOrder(order *model.PurchaseOrder) (*model.PurchaseOrder, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`INSERT INTO purchase_orders (id, supplier_id, status, note, total_cost, created_by, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,%[1]s,%[1]s)`, r.dialect.Now()),
+		order.ID,
+		order.SupplierID,
+		model.PurchaseOrderStatusDraft,
+		nullString(order.Note),
+		order.TotalCost,
+		order.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := insertPurchaseOrderItems(tx, order); err != nil {
+		return nil, err
+	}
+
+	created, err := getPurchaseOrder(tx, order.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *purchaseOrderRepository) GetPurchaseOrders(query *dto.PurchaseOrderQuery) ([]*model.PurchaseOrder, int, error) {
+	var whereClause strings.Builder
+	var args []any
+	argsIdx := 1
+
+	whereClause.WriteString("WHERE 1=1 ")
+
+	if query.Status != "" {
+		fmt.Fprintf(&whereClause, " AND po.status = $%d", argsIdx)
+		args = append(args, query.Status)
+		argsIdx++
+	}
+
+	if query.SupplierID != "" {
+		fmt.Fprintf(&whereClause, " AND po.supplier_id = $%d", argsIdx)
+		args = append(args, query.SupplierID)
+		argsIdx++
+	}
+
+	rows, err := r.db.Query(fmt.Sprintf(`
+		SELECT %s
+		FROM purchase_orders po
+		JOIN suppliers s ON s.id = po.supplier_id
+		%s
+		ORDER BY po.created_at DESC
+		LIMIT $%d OFFSET $%d`, purchaseOrderColumns, whereClause.String(), argsIdx, argsIdx+1),
+		append(args, query.Limit, query.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	orders := make([]*model.PurchaseOrder, 0)
+	for rows.Next() {
+		order, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		orders = append(orders, order)
+	}
+
+	if rows.Err() != nil {
+		return nil, 0, rows.Err()
+	}
+
+	var total int
+	err = r.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM purchase_orders po %s`, whereClause.String()),
+		args...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orders, total, nil
+}
+
+func (r *purchaseOrderRepository) GetPurchaseOrderByID(id string) (*model.PurchaseOrder, error) {
+	return getPurchaseOrder(r.db, id)
+}
+
+// UpdatePurchaseOrderByID mengganti supplier, catatan dan seluruh item. Hanya PO draft yang bisa diubah
+func (r *purchaseOrderRepository) UpdatePurchaseOrderByID(id string, order *model.PurchaseOrder) (*model.PurchaseOrder, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := r.lockPurchaseOrder(tx, id, model.PurchaseOrderStatusDraft); err != nil {
+		return nil, err
+	}
+
+	order.ID, err = uuid.FromString(id)
+	if err != nil {
+		return nil, utils.ErrPurchaseOrderNotFound
+	}
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`UPDATE purchase_orders SET supplier_id = $1, note = $2, total_cost = $3, updated_at = %s WHERE id = $4`, r.dialect.Now()),
+		order.SupplierID,
+		nullString(order.Note),
+		order.TotalCost,
+		order.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec("DELETE FROM purchase_order_items WHERE purchase_order_id = $1", order.ID); err != nil {
+		return nil, err
+	}
+
+	if err := insertPurchaseOrderItems(tx, order); err != nil {
+		return nil, err
+	}
+
+	updated, err := getPurchaseOrder(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (r *purchaseOrderRepository) SendPurchaseOrder(id string) (*model.PurchaseOrder, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := r.lockPurchaseOrder(tx, id, model.PurchaseOrderStatusDraft); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`UPDATE purchase_orders SET status = $1, sent_at = %[1]s, updated_at = %[1]s WHERE id = $2`, r.dialect.Now()),
+		model.PurchaseOrderStatusSent,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := getPurchaseOrder(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return sent, nil
+}
+
+// ReceivePurchaseOrder mencatat barang yang datang. Setiap item menambah stok lewat movement
+// receive dengan reference goods receipt. PO otomatis closed jika semua item sudah diterima penuh
+func (r *purchaseOrderRepository) ReceivePurchaseOrder(id string, receipt *model.GoodsReceipt) (*model.PurchaseOrder, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = r.lockPurchaseOrder(tx, id, model.PurchaseOrderStatusSent, model.PurchaseOrderStatusPartiallyReceived)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := getPurchaseOrder(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := make(map[uuid.UUID]model.PurchaseOrderItem, len(order.Items))
+	for _, item := range order.Items {
+		ordered[item.ProductID] = item
+	}
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`INSERT INTO goods_receipts (id, purchase_order_id, note, received_by, created_at)
+		VALUES ($1,$2,$3,$4,%s)`, r.dialect.Now()),
+		receipt.ID,
+		order.ID,
+		nullString(receipt.Note),
+		receipt.ReceivedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range receipt.Items {
+		orderItem, ok := ordered[item.ProductID]
+		if !ok {
+			return nil, fmt.Errorf("%w: product %s is not in this purchase order", utils.ErrInvalidPurchaseOrderItem, item.ProductID)
+		}
+
+		if item.Quantity > orderItem.Remaining() {
+			return nil, fmt.Errorf("%w: product %s remaining %d, received %d", utils.ErrOverReceipt, item.ProductID, orderItem.Remaining(), item.Quantity)
+		}
+
+		_, err := tx.Exec(
+			`INSERT INTO goods_receipt_items (receipt_id, purchase_order_item_id, product_id, quantity, unit_cost)
+			VALUES ($1,$2,$3,$4,$5)`,
+			receipt.ID,
+			orderItem.ID,
+			item.ProductID,
+			item.Quantity,
+			orderItem.UnitCost,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("insert goods receipt item failed: %w", err)
+		}
+
+		_, err = tx.Exec(
+			"UPDATE purchase_order_items SET received_quantity = received_quantity + $1 WHERE id = $2",
+			item.Quantity,
+			orderItem.ID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := updateMovingAverageCost(tx, item.ProductID, item.Quantity, orderItem.UnitCost); err != nil {
+			return nil, err
+		}
+
+		err = applyStockMovement(tx, r.dialect, &model.StockMovement{
+			ProductID:   item.ProductID,
+			Type:        model.StockMovementReceive,
+			Quantity:    item.Quantity,
+			Reason:      "penerimaan PO",
+			ReferenceID: &receipt.ID,
+			CreatedBy:   receipt.ReceivedBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var outstanding bool
+	err = tx.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM purchase_order_items WHERE purchase_order_id = $1 AND received_quantity < quantity)",
+		order.ID,
+	).Scan(&outstanding)
+	if err != nil {
+		return nil, err
+	}
+
+	if outstanding {
+		_, err = tx.Exec(
+			fmt.Sprintf(`UPDATE purchase_orders SET status = $1, updated_at = %s WHERE id = $2`, r.dialect.Now()),
+			model.PurchaseOrderStatusPartiallyReceived,
+			order.ID,
+		)
+	} else {
+		_, err = tx.Exec(
+			fmt.Sprintf(`UPDATE purchase_orders SET status = $1, closed_at = %[1]s, updated_at = %[1]s WHERE id = $2`, r.dialect.Now()),
+			model.PurchaseOrderStatusClosed,
+			order.ID,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	received, err := getPurchaseOrder(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return received, nil
+}
+
+// updateMovingAverageCost menghitung HPP rata-rata bergerak dari stok sebelum barang masuk:
+// (stok x HPP lama + qty x harga beli) / (stok + qty), dibulatkan ke rupiah terdekat.
+// Jika stok kosong HPP langsung memakai harga beli. Harus dipanggil sebelum stok ditambah
+func updateMovingAverageCost(tx *sql.Tx, productID uuid.UUID, quantity int, unitCost int64) error {
+	_, err := tx.Exec(`
+		UPDATE product SET cost = CASE
+			WHEN stock <= 0 THEN CAST($1 AS BIGINT)
+			ELSE (stock * cost + CAST($2 AS BIGINT) * CAST($1 AS BIGINT) + (stock + CAST($2 AS BIGINT)) / 2) / (stock + CAST($2 AS BIGINT))
+		END
+		WHERE id = $3`,
+		unitCost,
+		quantity,
+		productID,
+	)
+	if err != nil {
+		return fmt.Errorf("update moving average cost for product %s failed: %w", productID, err)
+	}
+
+	return nil
+}
+
+// ClosePurchaseOrder menutup PO tanpa menunggu sisa barang, misalnya supplier hanya kirim sebagian
+func (r *purchaseOrderRepository) ClosePurchaseOrder(id string) (*model.PurchaseOrder, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = r.lockPurchaseOrder(tx, id,
+		model.PurchaseOrderStatusDraft,
+		model.PurchaseOrderStatusSent,
+		model.PurchaseOrderStatusPartiallyReceived,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`UPDATE purchase_orders SET status = $1, closed_at = %[1]s, updated_at = %[1]s WHERE id = $2`, r.dialect.Now()),
+		model.PurchaseOrderStatusClosed,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	closed, err := getPurchaseOrder(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return closed, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestReceivePurchaseOrderCost(t *testing.T) {
+	db := newTestDB(t)
+	teh := seedProduct(t, db, "Teh", 6000, 5, false)
+	gula := seedProduct(t, db, "Gula", 15000, 0, false)
+	mustExec(t, db, "UPDATE product SET cost = 4000 WHERE id = $1", teh)
+	mustExec(t, db, "UPDATE product SET cost = 1000 WHERE id = $1", gula)
+
+	supplierID := newID(t)
+	mustExec(t, db, "INSERT INTO suppliers (id, name) VALUES ($1,$2)", supplierID, "Toko Grosir")
+
+	repo := NewPurchaseOrderRepository(db, database.SQLite)
+	order, err := repo.CreatePurchaseOrder(&model.PurchaseOrder{
+		ID:         newID(t),
+		SupplierID: supplierID,
+		Items: []model.PurchaseOrderItem{
+			{ID: newID(t), ProductID: teh, Quantity: 10, UnitCost: 5500},
+			{ID: newID(t), ProductID: gula, Quantity: 4, UnitCost: 12000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreatePurchaseOrder() error = %v", err)
+	}
+	if _, err := repo.SendPurchaseOrder(order.ID.String()); err != nil {
+		t.Fatalf("SendPurchaseOrder() error = %v", err)
+	}
+
+	type want struct {
+		productID uuid.UUID
+		cost      int64
+		stock     int
+	}
+	tests := []struct {
+		name       string
+		items      []model.GoodsReceiptItem
+		wantStatus string
+		want       []want
+	}{
+		{
+			// teh (5 x 4.000 + 3 x 5.500) / 8 = 4.563, gula stok kosong langsung pakai harga beli
+			name: "penerimaan sebagian",
+			items: []model.GoodsReceiptItem{
+				{ProductID: teh, Quantity: 3},
+				{ProductID: gula, Quantity: 4},
+			},
+			wantStatus: model.PurchaseOrderStatusPartiallyReceived,
+			want:       []want{{teh, 4563, 8}, {gula, 12000, 4}},
+		},
+		{
+			// (8 x 4.563 + 7 x 5.500) / 15 = 5.000,73 dibulatkan
+			name:       "sisa barang datang",
+			items:      []model.GoodsReceiptItem{{ProductID: teh, Quantity: 7}},
+			wantStatus: model.PurchaseOrderStatusClosed,
+			want:       []want{{teh, 5000, 15}, {gula, 12000, 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received, err := repo.ReceivePurchaseOrder(order.ID.String(), &model.GoodsReceipt{
+				ID:    newID(t),
+				Items: tt.items,
+			})
+			if err != nil {
+				t.Fatalf("ReceivePurchaseOrder() error = %v", err)
+			}
+			if received.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", received.Status, tt.wantStatus)
+			}
+
+			for _, w := range tt.want {
+				var cost int64
+				var stock int
+				if err := db.QueryRow("SELECT cost, stock FROM product WHERE id = $1", w.productID).Scan(&cost, &stock); err != nil {
+					t.Fatalf("read product: %v", err)
+				}
+				if cost != w.cost || stock != w.stock {
+					t.Errorf("product %s cost/stock = %d/%d, want %d/%d", w.productID, cost, stock, w.cost, w.stock)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,463 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/gofrs/uuid/v5"
+)
+
+type RefundRepository interface {
+	VoidTransaction(transactionID string, body *dto.VoidRequest) (*model.Refund, error)
+	RefundTransaction(transactionID string, body *dto.RefundRequest) (*model.Refund, error)
+	GetRefundsByTransactionID(transactionID string) ([]*model.Refund, error)
+}
+
+type refundRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewRefundRepository(db *sql.DB, dialect database.Dialect) RefundRepository {
+	return &refundRepository{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// refundableTransaction adalah transaksi yang dikunci untuk refund beserta total yang sudah direfund
+type refundableTransaction struct {
+	Subtotal       int64
+	TotalAmount    int64
+	RefundedAmount int64
+	Details        map[uuid.UUID]refundableDetail
+}
+
+// lineTotal adalah bagian total_amount (termasuk service charge dan PPN) untuk satu baris
+func (t *refundableTransaction) lineTotal(subtotal int64) int64 {
+	if t.Subtotal == 0 {
+		return 0
+	}
+
+	return subtotal * t.TotalAmount / t.Subtotal
+}
+
+// refundableDetail adalah baris transaction_details beserta jumlah yang sudah direfund.
+// Subtotal sebelum pajak, Total sudah termasuk bagian service charge dan PPN
+type refundableDetail struct {
+	ID               uuid.UUID
+	ProductID        uuid.UUID
+	Quantity         int
+	Subtotal         int64
+	Total            int64
+	RefundedQuantity int
+	RefundedSubtotal int64
+	RefundedAmount   int64
+}
+
+func (d refundableDetail) remaining() int {
+	return d.Quantity - d.RefundedQuantity
+}
+
+// prorate membagi nilai baris secara proporsional terhadap qty,
+// sisa terakhir mengambil seluruh sisa supaya tidak ada selisih pembulatan
+func (d refundableDetail) prorate(value, refunded int64, quantity int) int64 {
+	if quantity == d.remaining() {
+		return value - refunded
+	}
+
+	return value * int64(quantity) / int64(d.Quantity)
+}
+
+func (r *refundRepository) VoidTransaction(transactionID string, body *dto.VoidRequest) (*model.Refund, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	transaction, err := r.lockRefundableDetails(tx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.RefundItem, 0, len(transaction.Details))
+	for _, d := range transaction.Details {
+		if d.remaining() > 0 {
+			items = append(items, dto.RefundItem{
+				TransactionDetailID: d.ID,
+				Quantity:            d.remaining(),
+			})
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, utils.ErrTransactionAlreadyRefunded
+	}
+
+	refund, err := r.createRefund(tx, transactionID, model.RefundTypeVoid, body.Reason, body.UserID, transaction, items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+func (r *refundRepository) RefundTransaction(transactionID string, body *dto.RefundRequest) (*model.Refund, error) {
+	if len(body.Items) == 0 {
+		return nil, fmt.Errorf("%w: items cannot be empty", utils.ErrInvalidRefundItem)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	transaction, err := r.lockRefundableDetails(tx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	refund, err := r.createRefund(tx, transactionID, model.RefundTypePartial, body.Reason, body.UserID, transaction, body.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+func (r *refundRepository) GetRefundsByTransactionID(transactionID string) ([]*model.Refund, error) {
+	rows, err := r.db.Query(`
+		SELECT
+			r.id, r.transaction_id, r.type, r.reason, r.total_amount, r.shift_id, r.cashier_id, r.created_at,
+			rd.id, rd.transaction_detail_id, rd.product_id, rd.quantity, rd.subtotal, rd.amount, rd.created_at
+		FROM refunds r
+		JOIN refund_details rd ON rd.refund_id = r.id
+		WHERE r.transaction_id = $1
+		ORDER BY r.created_at, rd.id`,
+		transactionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := make([]*model.Refund, 0)
+	refundMap := make(map[uuid.UUID]*model.Refund)
+
+	for rows.Next() {
+		var refund model.Refund
+		var detail model.RefundDetail
+		if err := rows.Scan(
+			&refund.ID,
+			&refund.TransactionID,
+			&refund.Type,
+			&refund.Reason,
+			&refund.TotalAmount,
+			&refund.ShiftID,
+			&refund.CashierID,
+			&refund.CreatedAt,
+			&detail.ID,
+			&detail.TransactionDetailID,
+			&detail.ProductID,
+			&detail.Quantity,
+			&detail.Subtotal,
+			&detail.Amount,
+			&detail.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		existing, ok := refundMap[refund.ID]
+		if !ok {
+			refund.Details = make([]model.RefundDetail, 0)
+			existing = &refund
+			refundMap[refund.ID] = existing
+			refunds = append(refunds, existing)
+		}
+
+		detail.RefundID = existing.ID
+		existing.Details = append(existing.Details, detail)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return refunds, nil
+}
+
+// lockRefundableDetails mengunci baris transaksi (FOR UPDATE) supaya dua refund
+// paralel tidak melebihi jumlah yang dibeli
+func (r *refundRepository) lockRefundableDetails(tx *sql.Tx, transactionID string) (*refundableTransaction, error) {
+	var status string
+	var transaction refundableTransaction
+	err := tx.QueryRow(
+		fmt.Sprintf(`SELECT status, subtotal, total_amount FROM transactions WHERE id = $1 %s`, r.dialect.ForUpdate()),
+		transactionID,
+	).Scan(&status, &transaction.Subtotal, &transaction.TotalAmount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+
+	if status == model.TransactionStatusVoided || status == model.TransactionStatusRefunded {
+		return nil, utils.ErrTransactionAlreadyRefunded
+	}
+
+	err = tx.QueryRow(
+		`SELECT CAST(COALESCE(SUM(total_amount), 0) AS BIGINT) FROM refunds WHERE transaction_id = $1`,
+		transactionID,
+	).Scan(&transaction.RefundedAmount)
+	if err != nil {
+		return nil, fmt.Errorf("query refunded amount failed: %w", err)
+	}
+
+	rows, err := tx.Query(`
+		SELECT
+			td.id,
+			td.product_id,
+			td.quantity,
+			td.subtotal,
+			COALESCE(SUM(rd.quantity), 0),
+			COALESCE(SUM(rd.subtotal), 0),
+			COALESCE(SUM(rd.amount), 0)
+		FROM transaction_details td
+		LEFT JOIN refund_details rd ON rd.transaction_detail_id = td.id
+		WHERE td.transaction_id = $1
+		GROUP BY td.id, td.product_id, td.quantity, td.subtotal
+		ORDER BY td.id`,
+		transactionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query transaction details failed: %w", err)
+	}
+	defer rows.Close()
+
+	transaction.Details = make(map[uuid.UUID]refundableDetail)
+	for rows.Next() {
+		var d refundableDetail
+		if err := rows.Scan(
+			&d.ID,
+			&d.ProductID,
+			&d.Quantity,
+			&d.Subtotal,
+			&d.RefundedQuantity,
+			&d.RefundedSubtotal,
+			&d.RefundedAmount,
+		); err != nil {
+			return nil, fmt.Errorf("scan transaction detail failed: %w", err)
+		}
+		d.Total = transaction.lineTotal(d.Subtotal)
+		transaction.Details[d.ID] = d
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate transaction detail failed: %w", err)
+	}
+
+	return &transaction, nil
+}
+
+func (r *refundRepository) createRefund(
+	tx *sql.Tx,
+	transactionID string,
+	refundType string,
+	reason string,
+	userID *uuid.UUID,
+	transaction *refundableTransaction,
+	items []dto.RefundItem,
+) (*model.Refund, error) {
+	details := transaction.Details
+
+	// uang refund keluar dari laci shift yang sedang dibuka user yang memproses refund
+	if userID == nil {
+		return nil, utils.ErrNoOpenShift
+	}
+
+	shiftID, err := openShiftID(tx, r.dialect, *userID)
+	if err != nil {
+		return nil, err
+	}
+
+	refundID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed generate id: %w", err)
+	}
+
+	parsedTransactionID, err := uuid.FromString(transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// gabungkan item dengan detail yang sama supaya validasi sisa qty akurat
+	requested := make(map[uuid.UUID]int)
+	order := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if _, ok := details[item.TransactionDetailID]; !ok {
+			return nil, fmt.Errorf("%w: detail %s does not belong to transaction %s",
+				utils.ErrInvalidRefundItem, item.TransactionDetailID, transactionID)
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity must be greater than 0", utils.ErrInvalidRefundItem)
+		}
+		if _, seen := requested[item.TransactionDetailID]; !seen {
+			order = append(order, item.TransactionDetailID)
+		}
+		requested[item.TransactionDetailID] += item.Quantity
+	}
+
+	totalAmount := int64(0)
+	refundDetails := make([]model.RefundDetail, 0, len(order))
+	for _, detailID := range order {
+		d := details[detailID]
+		quantity := requested[detailID]
+		if quantity > d.remaining() {
+			return nil, fmt.Errorf("%w: detail %s refundable quantity %d, requested %d",
+				utils.ErrInvalidRefundItem, detailID, d.remaining(), quantity)
+		}
+
+		err := applyStockMovement(tx, r.dialect, &model.StockMovement{
+			ProductID:   d.ProductID,
+			Type:        model.StockMovementRefund,
+			Quantity:    quantity,
+			Reason:      reason,
+			ReferenceID: &refundID,
+			CreatedBy:   userID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to restock product %s: %w", d.ProductID, err)
+		}
+
+		refundDetailID, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("generate refund detail id failed: %w", err)
+		}
+
+		subtotal := d.prorate(d.Subtotal, d.RefundedSubtotal, quantity)
+		amount := d.prorate(d.Total, d.RefundedAmount, quantity)
+		totalAmount += amount
+
+		refundDetails = append(refundDetails, model.RefundDetail{
+			ID:                  refundDetailID,
+			RefundID:            refundID,
+			TransactionDetailID: detailID,
+			ProductID:           d.ProductID,
+			Quantity:            quantity,
+			Subtotal:            subtotal,
+			Amount:              amount,
+		})
+
+		d.RefundedQuantity += quantity
+		d.RefundedSubtotal += subtotal
+		d.RefundedAmount += amount
+		details[detailID] = d
+	}
+
+	fullyRefunded := true
+	for _, d := range details {
+		if d.remaining() > 0 {
+			fullyRefunded = false
+			break
+		}
+	}
+
+	// refund terakhir mengambil seluruh sisa total_amount supaya total refund sama
+	// dengan yang dibayar pelanggan, selisih pembulatan antar baris masuk ke baris terakhir
+	if fullyRefunded && len(refundDetails) > 0 {
+		rest := transaction.TotalAmount - transaction.RefundedAmount - totalAmount
+		refundDetails[len(refundDetails)-1].Amount += rest
+		totalAmount += rest
+	}
+
+	var createdAt time.Time
+	err = tx.QueryRow(
+		fmt.Sprintf(`INSERT INTO refunds (id, transaction_id, type, reason, total_amount, shift_id, cashier_id, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,%s)
+		RETURNING created_at`, r.dialect.Now()),
+		refundID,
+		transactionID,
+		refundType,
+		reason,
+		totalAmount,
+		shiftID,
+		userID,
+	).Scan(&createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.bulkInsertRefundDetails(tx, refundDetails); err != nil {
+		return nil, err
+	}
+
+	status := model.TransactionStatusRefunded
+	if refundType == model.RefundTypeVoid {
+		status = model.TransactionStatusVoided
+	} else if !fullyRefunded {
+		status = model.TransactionStatusPartiallyRefunded
+	}
+
+	_, err = tx.Exec("UPDATE transactions SET status = $1 WHERE id = $2", status, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	for i := range refundDetails {
+		refundDetails[i].CreatedAt = createdAt
+	}
+
+	return &model.Refund{
+		ID:            refundID,
+		TransactionID: parsedTransactionID,
+		Type:          refundType,
+		Reason:        reason,
+		TotalAmount:   totalAmount,
+		ShiftID:       shiftID,
+		CashierID:     userID,
+		CreatedAt:     createdAt,
+		Details:       refundDetails,
+	}, nil
+}
+
+func (r *refundRepository) bulkInsertRefundDetails(tx *sql.Tx, details []model.RefundDetail) error {
+	if len(details) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(details))
+	args := make([]any, 0, len(details)*7)
+	for i, d := range details {
+		args = append(args, d.ID, d.RefundID, d.TransactionDetailID, d.ProductID, d.Quantity, d.Subtotal, d.Amount)
+		valueStrings[i] = fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d,$%d,%s)", i*7+1, i*7+2, i*7+3, i*7+4, i*7+5, i*7+6, i*7+7, r.dialect.Now())
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO refund_details (id,refund_id,transaction_detail_id,product_id,quantity,subtotal,amount,created_at) VALUES %s",
+		strings.Join(valueStrings, ","),
+	)
+
+	_, err := tx.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("bulk insert refund details failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+)
+
+func TestRefundTransactionProration(t *testing.T) {
+	db := newTestDB(t)
+	cashierID := seedCashier(t, db, "kasir")
+	productID := seedProduct(t, db, "Roti", 3333, 5, false)
+
+	// subtotal 9.999 + PPN 1.100 = 11.099, tidak habis dibagi 3
+	transaction := checkout(t, db, cashierID, dto.CheckoutItem{ProductID: productID, Quantity: 3})
+	if transaction.TotalAmount != 11099 {
+		t.Fatalf("TotalAmount = %d, want 11099", transaction.TotalAmount)
+	}
+	detailID := transaction.Details[0].ID
+
+	repo := NewRefundRepository(db, database.SQLite)
+	tests := []struct {
+		name         string
+		quantity     int
+		wantSubtotal int64
+		wantAmount   int64
+		wantStock    int
+	}{
+		{name: "sebagian", quantity: 1, wantSubtotal: 3333, wantAmount: 3699, wantStock: 3},
+		{name: "sisa menyerap pembulatan", quantity: 2, wantSubtotal: 6666, wantAmount: 7400, wantStock: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refund, err := repo.RefundTransaction(transaction.ID.String(), &dto.RefundRequest{
+				Reason: tt.name,
+				Items:  []dto.RefundItem{{TransactionDetailID: detailID, Quantity: tt.quantity}},
+				UserID: &cashierID,
+			})
+			if err != nil {
+				t.Fatalf("RefundTransaction() error = %v", err)
+			}
+
+			if refund.TotalAmount != tt.wantAmount {
+				t.Errorf("TotalAmount = %d, want %d", refund.TotalAmount, tt.wantAmount)
+			}
+			if got := refund.Details[0].Subtotal; got != tt.wantSubtotal {
+				t.Errorf("Details[0].Subtotal = %d, want %d", got, tt.wantSubtotal)
+			}
+			if got := productStock(t, db, productID); got != tt.wantStock {
+				t.Errorf("stock = %d, want %d", got, tt.wantStock)
+			}
+		})
+	}
+
+	var refunded int64
+	if err := db.QueryRow("SELECT SUM(total_amount) FROM refunds WHERE transaction_id = $1", transaction.ID).Scan(&refunded); err != nil {
+		t.Fatalf("read refunds: %v", err)
+	}
+	if refunded != transaction.TotalAmount {
+		t.Errorf("refunded = %d, want %d", refunded, transaction.TotalAmount)
+	}
+
+	_, err := repo.VoidTransaction(transaction.ID.String(), &dto.VoidRequest{Reason: "void", UserID: &cashierID})
+	if !errors.Is(err, utils.ErrTransactionAlreadyRefunded) {
+		t.Errorf("VoidTransaction() error = %v, want %v", err, utils.ErrTransactionAlreadyRefunded)
+	}
+}
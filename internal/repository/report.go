@@ -3,14 +3,20 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/Muh-Sidik/kasir-api/internal/model"
 	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/gofrs/uuid/v5"
 )
 
 type ReportRepository interface {
 	Report(param *dto.ReportParam) (*model.TopProductReport, error)
+	ShiftReport(shiftID string) (*model.ShiftReport, error)
+	MarginReport(param *dto.MarginReportParam) (*model.MarginReport, error)
+	ModifierReport(param *dto.ModifierReportParam) ([]model.ModifierReportItem, error)
 }
 
 type reportRepository struct {
@@ -23,44 +29,103 @@ func NewReportRepository(db *sql.DB) ReportRepository {
 	}
 }
 
+const dateLayout = "2006-01-02"
+
+// startOfDay mengubah tanggal "YYYY-MM-DD" menjadi awal hari waktu lokal dalam UTC,
+// batas waktu dihitung di Go supaya query sama untuk postgres dan sqlite
+func startOfDay(date string) (time.Time, error) {
+	day, err := time.ParseInLocation(dateLayout, date, time.Local)
+	if err != nil {
+		return time.Time{}, apperror.BadRequest("INVALID_DATE", fmt.Errorf("invalid date %q: %w", date, err))
+	}
+
+	return day.UTC(), nil
+}
+
+// reportRange mengubah start_date dan end_date (default hari ini) menjadi rentang waktu
+// dengan batas akhir eksklusif: awal hari setelah end_date
+func reportRange(startDate, endDate string) (time.Time, time.Time, error) {
+	if startDate == "" {
+		startDate = time.Now().Format(dateLayout)
+	}
+	if endDate == "" {
+		endDate = time.Now().Format(dateLayout)
+	}
+
+	start, err := startOfDay(startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	end, err := startOfDay(endDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return start, end.AddDate(0, 0, 1), nil
+}
+
 func (r *reportRepository) Report(param *dto.ReportParam) (*model.TopProductReport, error) {
+	// Revenue dan qty terjual dihitung net setelah refund,
+	// transaksi yang di-void tidak dihitung sama sekali
 	query := `
-		WITH report_data AS (
-			SELECT 
-				COALESCE(SUM(td.subtotal), 0)::BIGINT as total_revenue,
-				COALESCE(COUNT(DISTINCT t.id), 0)::BIGINT as total_transaction,
-				p.name as top_product_name,
-				COALESCE(SUM(td.quantity), 0)::BIGINT as product_quantity,
-				ROW_NUMBER() OVER (ORDER BY SUM(td.quantity) DESC) as rn
+		WITH refunded AS (
+			SELECT
+				transaction_detail_id,
+				SUM(quantity) as quantity,
+				SUM(subtotal) as amount
+			FROM refund_details
+			GROUP BY transaction_detail_id
+		),
+		sales AS (
+			SELECT
+				t.id as transaction_id,
+				td.product_id,
+				td.product_name,
+				td.quantity - COALESCE(r.quantity, 0) as quantity,
+				td.subtotal - COALESCE(r.amount, 0) as amount
 			FROM transactions t
 			JOIN transaction_details td ON t.id = td.transaction_id
-			JOIN product p ON td.product_id = p.id
-			WHERE t.created_at >= $1::date 
-			  AND t.created_at < ($2::date + INTERVAL '1 day')
-			GROUP BY p.id, p.name
+			LEFT JOIN refunded r ON r.transaction_detail_id = td.id
+			WHERE t.created_at >= $1
+			  AND t.created_at < $2
+			  AND t.status NOT IN ('voided', 'refunded')
+			  %s
+		),
+		top_product AS (
+			SELECT
+				MAX(s.product_name) as top_product_name,
+				SUM(s.quantity) as product_quantity
+			FROM sales s
+			GROUP BY s.product_id
+			HAVING SUM(s.quantity) > 0
+			ORDER BY SUM(s.quantity) DESC
+			LIMIT 1
 		)
-		SELECT 
-			total_revenue,
-			total_transaction,
-			top_product_name,
-			product_quantity
-		FROM report_data
-		WHERE rn = 1
+		SELECT
+			CAST((SELECT COALESCE(SUM(amount), 0) FROM sales) AS BIGINT) as total_revenue,
+			CAST((SELECT COUNT(DISTINCT transaction_id) FROM sales) AS BIGINT) as total_transaction,
+			COALESCE(tp.top_product_name, '') as top_product_name,
+			CAST(COALESCE(tp.product_quantity, 0) AS BIGINT) as product_quantity
+		FROM (SELECT 1) base
+		LEFT JOIN top_product tp ON TRUE
 	`
 
-	// Handle default date (today jika kosong)
-	startDate := param.StartDate
-	endDate := param.EndDate
-
-	if startDate == "" {
-		startDate = time.Now().Format("2006-01-02")
+	start, end, err := reportRange(param.StartDate, param.EndDate)
+	if err != nil {
+		return nil, err
 	}
-	if endDate == "" {
-		endDate = time.Now().Format("2006-01-02")
+
+	// filter kasir opsional, selalu memakai alias t dan parameter ketiga
+	args := []any{start, end}
+	cashierFilter := ""
+	if param.CashierID != "" {
+		cashierFilter = "AND t.cashier_id = $3"
+		args = append(args, param.CashierID)
 	}
 
 	var result model.TopProductReport
-	err := r.db.QueryRow(query, startDate, endDate).Scan(
+	err = r.db.QueryRow(fmt.Sprintf(query, cashierFilter), args...).Scan(
 		&result.TotalRevenue,
 		&result.TotalTransaction,
 		&result.Products.ProductName,
@@ -77,10 +142,561 @@ func (r *reportRepository) Report(param *dto.ReportParam) (*model.TopProductRepo
 					ProductName:  "",
 					QuantitySold: 0,
 				},
+				PaymentMethods: make([]model.PaymentMethodReport, 0),
 			}, nil
 		}
 		return nil, fmt.Errorf("query report failed: %w", err)
 	}
 
+	// refund parsial juga dikurangkan dari rincian pembayaran dan pajak
+	// supaya angkanya cocok dengan total_revenue
+	result.PaymentMethods, err = r.paymentMethods(
+		"t.created_at >= $1 AND t.created_at < $2 AND t.status NOT IN ('voided', 'refunded') "+cashierFilter,
+		args,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Tax, err = r.taxSummary(cashierFilter, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if param.GroupBy == dto.ReportGroupByCashier {
+		result.Cashiers, err = r.cashiers(cashierFilter, args)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &result, nil
 }
+
+// paymentMethods merangkum uang yang diterima per metode pembayaran (setelah dikurangi kembalian
+// dan refund parsial), where adalah kondisi untuk transactions t
+func (r *reportRepository) paymentMethods(where string, args []any) ([]model.PaymentMethodReport, error) {
+	rows, err := r.db.Query(fmt.Sprintf(`
+		SELECT
+			tp.method,
+			CAST(COALESCE(SUM(%s), 0) AS BIGINT) as total_amount,
+			CAST(COUNT(DISTINCT t.id) AS BIGINT) as total_transaction
+		FROM transactions t
+		JOIN transaction_payments tp ON tp.transaction_id = t.id
+		LEFT JOIN %s r ON r.transaction_id = t.id
+		WHERE %s
+		GROUP BY tp.method
+		ORDER BY total_amount DESC
+	`, netPayment, partialRefunds, where), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query payment method report failed: %w", err)
+	}
+	defer rows.Close()
+
+	methods := make([]model.PaymentMethodReport, 0)
+	for rows.Next() {
+		var method model.PaymentMethodReport
+		if err := rows.Scan(
+			&method.Method,
+			&method.TotalAmount,
+			&method.TotalTransaction,
+		); err != nil {
+			return nil, fmt.Errorf("scan payment method report failed: %w", err)
+		}
+		methods = append(methods, method)
+	}
+
+	return methods, rows.Err()
+}
+
+// shiftPaymentMethods seperti paymentMethods untuk satu shift: pembayaran semua transaksi
+// shift ini dikurangi bagian void dan refund yang diproses di shift ini per metode pembayaran
+func (r *reportRepository) shiftPaymentMethods(shiftID uuid.UUID) ([]model.PaymentMethodReport, error) {
+	rows, err := r.db.Query(fmt.Sprintf(`
+		SELECT
+			method,
+			CAST(COALESCE(SUM(amount), 0) AS BIGINT) as total_amount,
+			CAST(COALESCE(SUM(total_transaction), 0) AS BIGINT) as total_transaction
+		FROM (
+			SELECT tp.method, SUM(tp.amount - tp.change_amount) as amount, COUNT(DISTINCT t.id) as total_transaction
+			FROM transactions t
+			JOIN transaction_payments tp ON tp.transaction_id = t.id
+			WHERE t.shift_id = $1
+			GROUP BY tp.method
+			UNION ALL
+			SELECT tp.method, -SUM(%s) as amount, 0 as total_transaction
+			FROM refunds r
+			JOIN transactions t ON t.id = r.transaction_id
+			JOIN transaction_payments tp ON tp.transaction_id = t.id
+			WHERE r.shift_id = $1
+			GROUP BY tp.method
+		) m
+		GROUP BY method
+		ORDER BY total_amount DESC
+	`, shiftRefundShare("(tp.amount - tp.change_amount)")), shiftID)
+	if err != nil {
+		return nil, fmt.Errorf("query shift payment method report failed: %w", err)
+	}
+	defer rows.Close()
+
+	methods := make([]model.PaymentMethodReport, 0)
+	for rows.Next() {
+		var method model.PaymentMethodReport
+		if err := rows.Scan(
+			&method.Method,
+			&method.TotalAmount,
+			&method.TotalTransaction,
+		); err != nil {
+			return nil, fmt.Errorf("scan shift payment method report failed: %w", err)
+		}
+		methods = append(methods, method)
+	}
+
+	return methods, rows.Err()
+}
+
+// taxSummary merangkum DPP, PPN dan service charge untuk pelaporan pajak. Subtotal dan total
+// dikurangi refund parsial, service charge, DPP dan PPN dikurangi sebanding nominal refund
+func (r *reportRepository) taxSummary(cashierFilter string, args []any) (model.TaxReport, error) {
+	var tax model.TaxReport
+	err := r.db.QueryRow(fmt.Sprintf(`
+		SELECT
+			CAST(COALESCE(SUM(t.subtotal - COALESCE(r.subtotal, 0)), 0) AS BIGINT),
+			CAST(COALESCE(SUM(t.service_charge - %s), 0) AS BIGINT),
+			CAST(COALESCE(SUM(t.tax_base - %s), 0) AS BIGINT),
+			CAST(COALESCE(SUM(t.tax_amount - %s), 0) AS BIGINT),
+			CAST(COALESCE(SUM(t.total_amount - COALESCE(r.amount, 0)), 0) AS BIGINT)
+		FROM transactions t
+		LEFT JOIN %s r ON r.transaction_id = t.id
+		WHERE t.created_at >= $1
+		  AND t.created_at < $2
+		  AND t.status NOT IN ('voided', 'refunded')
+		  %s
+	`,
+		refundShare("t.service_charge"),
+		refundShare("t.tax_base"),
+		refundShare("t.tax_amount"),
+		partialRefunds,
+		cashierFilter,
+	), args...).Scan(
+		&tax.Subtotal,
+		&tax.ServiceCharge,
+		&tax.TaxBase,
+		&tax.TaxAmount,
+		&tax.TotalAmount,
+	)
+	if err != nil {
+		return tax, fmt.Errorf("query tax report failed: %w", err)
+	}
+
+	return tax, nil
+}
+
+// partialRefunds menjumlahkan refund parsial per transaksi: subtotal sebelum pajak dan
+// amount yang dikembalikan ke pelanggan. Void tidak ikut karena transaksinya sudah dikeluarkan
+const partialRefunds = `(
+	SELECT
+		rf.transaction_id,
+		SUM(rd.subtotal) as subtotal,
+		SUM(rd.amount) as amount
+	FROM refunds rf
+	JOIN refund_details rd ON rd.refund_id = rf.id
+	WHERE rf.type = 'partial'
+	GROUP BY rf.transaction_id
+)`
+
+// refundShare adalah bagian kolom transaksi yang ikut direfund, sebanding dengan
+// nominal refund terhadap total_amount. Dipakai bersama LEFT JOIN partialRefunds r
+func refundShare(column string) string {
+	return fmt.Sprintf("COALESCE(%s * r.amount / NULLIF(t.total_amount, 0), 0)", column)
+}
+
+// shiftRefundShare adalah bagian kolom transaksi t yang dikembalikan oleh satu baris refunds r,
+// sebanding total_amount refund terhadap total_amount transaksi. Void mengembalikan seluruhnya
+func shiftRefundShare(column string) string {
+	return fmt.Sprintf("%s * r.total_amount / NULLIF(t.total_amount, 0)", column)
+}
+
+// netPayment adalah uang yang diterima satu baris pembayaran setelah kembalian dan
+// bagian refund parsialnya. Refund dibagi ke metode pembayaran sebanding uang yang diterima
+var netPayment = "(tp.amount - tp.change_amount) - " + refundShare("(tp.amount - tp.change_amount)")
+
+// cashiers merangkum penjualan, refund dan void per kasir. Transaksi lama tanpa
+// kasir dikelompokkan dengan kasir_id kosong
+func (r *reportRepository) cashiers(cashierFilter string, args []any) ([]model.CashierReport, error) {
+	rows, err := r.db.Query(fmt.Sprintf(`
+		WITH refunded AS %s
+		SELECT
+			COALESCE(CAST(t.cashier_id AS TEXT), '') as cashier_id,
+			COALESCE(u.username, '') as username,
+			COALESCE(u.name, '') as name,
+			CAST(SUM(CASE WHEN t.status NOT IN ('voided', 'refunded') THEN 1 ELSE 0 END) AS BIGINT) as total_transaction,
+			CAST(COALESCE(SUM(CASE WHEN t.status NOT IN ('voided', 'refunded') THEN t.subtotal - COALESCE(r.subtotal, 0) ELSE 0 END), 0) AS BIGINT) as total_revenue,
+			CAST(COALESCE(SUM(r.amount), 0) AS BIGINT) as total_refund,
+			CAST(SUM(CASE WHEN t.status = 'voided' THEN 1 ELSE 0 END) AS BIGINT) as void_count,
+			CAST(COALESCE(SUM(CASE WHEN t.status = 'voided' THEN t.total_amount ELSE 0 END), 0) AS BIGINT) as total_void
+		FROM transactions t
+		LEFT JOIN refunded r ON r.transaction_id = t.id
+		LEFT JOIN users u ON u.id = t.cashier_id
+		WHERE t.created_at >= $1
+		  AND t.created_at < $2
+		  %s
+		GROUP BY t.cashier_id, u.username, u.name
+		ORDER BY total_revenue DESC
+	`, partialRefunds, cashierFilter), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query cashier report failed: %w", err)
+	}
+	defer rows.Close()
+
+	cashiers := make([]model.CashierReport, 0)
+	for rows.Next() {
+		var cashier model.CashierReport
+		if err := rows.Scan(
+			&cashier.CashierID,
+			&cashier.Username,
+			&cashier.Name,
+			&cashier.TotalTransaction,
+			&cashier.TotalRevenue,
+			&cashier.TotalRefund,
+			&cashier.VoidCount,
+			&cashier.TotalVoid,
+		); err != nil {
+			return nil, fmt.Errorf("scan cashier report failed: %w", err)
+		}
+		cashiers = append(cashiers, cashier)
+	}
+
+	return cashiers, rows.Err()
+}
+
+// marginSales adalah penjualan net setelah refund per baris transaksi beserta HPP-nya,
+// kondisinya sama dengan Report supaya revenue kedua laporan cocok. Nama produk dan
+// kategori diambil dari snapshot transaksi, bukan master produk saat ini
+const marginSales = `
+	WITH refunded AS (
+		SELECT
+			transaction_detail_id,
+			SUM(quantity) as quantity,
+			SUM(subtotal) as amount
+		FROM refund_details
+		GROUP BY transaction_detail_id
+	),
+	sales AS (
+		SELECT
+			t.id as transaction_id,
+			t.created_at,
+			td.product_id,
+			td.product_name,
+			td.category_id,
+			td.category_name,
+			td.quantity - COALESCE(r.quantity, 0) as quantity,
+			td.subtotal - COALESCE(r.amount, 0) as revenue,
+			(td.quantity - COALESCE(r.quantity, 0)) * td.unit_cost as cost
+		FROM transactions t
+		JOIN transaction_details td ON t.id = td.transaction_id
+		LEFT JOIN refunded r ON r.transaction_detail_id = td.id
+		WHERE t.created_at >= $1
+		  AND t.created_at < $2
+		  AND t.status NOT IN ('voided', 'refunded')
+	)`
+
+// MarginReport menghitung laba kotor per produk, kategori atau periode. Periode dikelompokkan
+// di Go memakai zona waktu lokal supaya sama untuk postgres dan sqlite
+func (r *reportRepository) MarginReport(param *dto.MarginReportParam) (*model.MarginReport, error) {
+	start, end, err := reportRange(param.StartDate, param.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report := model.MarginReport{
+		GroupBy: param.GroupBy,
+		Items:   make([]model.MarginItem, 0),
+	}
+
+	switch param.GroupBy {
+	case dto.MarginGroupByDay, dto.MarginGroupByMonth:
+		report.Items, err = r.marginByPeriod(param.GroupBy, start, end)
+	case dto.MarginGroupByCategory:
+		report.Items, err = r.marginByGroup("CAST(category_id AS TEXT)", "category_name", start, end)
+	default:
+		report.Items, err = r.marginByGroup("CAST(product_id AS TEXT)", "product_name", start, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range report.Items {
+		report.Revenue += item.Revenue
+		report.Cost += item.Cost
+	}
+	report.GrossProfit = report.Revenue - report.Cost
+	report.Margin = marginPercent(report.GrossProfit, report.Revenue)
+
+	return &report, nil
+}
+
+func (r *reportRepository) marginByGroup(idColumn, nameColumn string, start, end time.Time) ([]model.MarginItem, error) {
+	rows, err := r.db.Query(fmt.Sprintf(`%s
+		SELECT
+			%[2]s,
+			MAX(%[3]s),
+			CAST(COALESCE(SUM(quantity), 0) AS BIGINT),
+			CAST(COALESCE(SUM(revenue), 0) AS BIGINT),
+			CAST(COALESCE(SUM(cost), 0) AS BIGINT)
+		FROM sales
+		GROUP BY %[2]s
+		ORDER BY SUM(revenue) - SUM(cost) DESC`, marginSales, idColumn, nameColumn),
+		start,
+		end,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query margin report failed: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]model.MarginItem, 0)
+	for rows.Next() {
+		var item model.MarginItem
+		if err := rows.Scan(
+			&item.ID,
+			&item.Name,
+			&item.QuantitySold,
+			&item.Revenue,
+			&item.Cost,
+		); err != nil {
+			return nil, fmt.Errorf("scan margin report failed: %w", err)
+		}
+		item.GrossProfit = item.Revenue - item.Cost
+		item.Margin = marginPercent(item.GrossProfit, item.Revenue)
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *reportRepository) marginByPeriod(groupBy string, start, end time.Time) ([]model.MarginItem, error) {
+	rows, err := r.db.Query(marginSales+`
+		SELECT
+			created_at,
+			CAST(COALESCE(SUM(quantity), 0) AS BIGINT),
+			CAST(COALESCE(SUM(revenue), 0) AS BIGINT),
+			CAST(COALESCE(SUM(cost), 0) AS BIGINT)
+		FROM sales
+		GROUP BY transaction_id, created_at
+		ORDER BY created_at`,
+		start,
+		end,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query margin report failed: %w", err)
+	}
+	defer rows.Close()
+
+	layout := dateLayout
+	if groupBy == dto.MarginGroupByMonth {
+		layout = "2006-01"
+	}
+
+	items := make([]model.MarginItem, 0)
+	for rows.Next() {
+		var createdAt time.Time
+		var quantity, revenue, cost int64
+		if err := rows.Scan(&createdAt, &quantity, &revenue, &cost); err != nil {
+			return nil, fmt.Errorf("scan margin report failed: %w", err)
+		}
+
+		period := createdAt.Local().Format(layout)
+		if n := len(items); n == 0 || items[n-1].Name != period {
+			items = append(items, model.MarginItem{Name: period})
+		}
+		item := &items[len(items)-1]
+		item.QuantitySold += quantity
+		item.Revenue += revenue
+		item.Cost += cost
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		items[i].GrossProfit = items[i].Revenue - items[i].Cost
+		items[i].Margin = marginPercent(items[i].GrossProfit, items[i].Revenue)
+	}
+
+	return items, nil
+}
+
+// marginPercent mengembalikan laba kotor terhadap revenue dalam persen, dua angka desimal
+// ModifierReport merangkum modifier terjual dari snapshot transaction_detail_modifiers,
+// dikelompokkan per modifier_id dengan nama terakhir yang tercatat
+func (r *reportRepository) ModifierReport(param *dto.ModifierReportParam) ([]model.ModifierReportItem, error) {
+	start, end, err := reportRange(param.StartDate, param.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`
+		WITH refunded AS (
+			SELECT
+				transaction_detail_id,
+				SUM(quantity) as quantity
+			FROM refund_details
+			GROUP BY transaction_detail_id
+		),
+		sales AS (
+			SELECT
+				m.modifier_id,
+				m.group_name,
+				m.name,
+				td.quantity - COALESCE(r.quantity, 0) as quantity,
+				(td.quantity - COALESCE(r.quantity, 0)) * m.price_delta as revenue
+			FROM transactions t
+			JOIN transaction_details td ON t.id = td.transaction_id
+			JOIN transaction_detail_modifiers m ON m.transaction_detail_id = td.id
+			LEFT JOIN refunded r ON r.transaction_detail_id = td.id
+			WHERE t.created_at >= $1
+			  AND t.created_at < $2
+			  AND t.status NOT IN ('voided', 'refunded')
+		)
+		SELECT
+			CAST(modifier_id AS TEXT),
+			MAX(group_name),
+			MAX(name),
+			CAST(COALESCE(SUM(quantity), 0) AS BIGINT),
+			CAST(COALESCE(SUM(revenue), 0) AS BIGINT)
+		FROM sales
+		GROUP BY modifier_id
+		HAVING SUM(quantity) > 0
+		ORDER BY SUM(quantity) DESC`,
+		start,
+		end,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query modifier report failed: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]model.ModifierReportItem, 0)
+	for rows.Next() {
+		var item model.ModifierReportItem
+		if err := rows.Scan(
+			&item.ID,
+			&item.GroupName,
+			&item.Name,
+			&item.QuantitySold,
+			&item.Revenue,
+		); err != nil {
+			return nil, fmt.Errorf("scan modifier report failed: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func marginPercent(grossProfit, revenue int64) float64 {
+	if revenue == 0 {
+		return 0
+	}
+
+	return math.Round(float64(grossProfit)*10000/float64(revenue)) / 100
+}
+
+// ShiftReport membuat laporan X selama shift masih buka dan laporan Z setelah ditutup.
+// Berbeda dengan Report, penjualan dihitung dari semua transaksi shift ini lalu dikurangi
+// void dan refund yang diproses di shift ini (bukan shift transaksi asalnya), sehingga
+// laporan Z tidak berubah oleh refund setelah shift ditutup
+func (r *reportRepository) ShiftReport(shiftID string) (*model.ShiftReport, error) {
+	shift, err := getShift(r.db, shiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := model.ShiftReport{
+		Type:  model.ShiftReportX,
+		Shift: *shift,
+	}
+	if shift.Status == model.ShiftStatusClosed {
+		report.Type = model.ShiftReportZ
+	}
+
+	var grossSales int64
+	err = r.db.QueryRow(`
+		SELECT
+			CAST((SELECT COUNT(*) FROM transactions WHERE shift_id = $1) AS BIGINT),
+			CAST(COALESCE((SELECT SUM(total_amount) FROM transactions WHERE shift_id = $1), 0) AS BIGINT),
+			CAST(COALESCE(SUM(CASE WHEN type = 'void' THEN 1 ELSE 0 END), 0) AS BIGINT),
+			CAST(COALESCE(SUM(CASE WHEN type = 'void' THEN total_amount ELSE 0 END), 0) AS BIGINT),
+			CAST(COALESCE(SUM(CASE WHEN type = 'partial' THEN total_amount ELSE 0 END), 0) AS BIGINT)
+		FROM refunds
+		WHERE shift_id = $1
+	`, shift.ID).Scan(
+		&report.TotalTransaction,
+		&grossSales,
+		&report.VoidCount,
+		&report.TotalVoid,
+		&report.TotalRefund,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query shift report failed: %w", err)
+	}
+	report.TotalSales = grossSales - report.TotalVoid - report.TotalRefund
+
+	report.PaymentMethods, err = r.shiftPaymentMethods(shift.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Cash, err = shiftCash(r.db, shift)
+	if err != nil {
+		return nil, err
+	}
+
+	// laporan Z memakai angka yang dikunci saat tutup shift
+	if shift.ExpectedCash != nil {
+		report.Cash.ExpectedCash = *shift.ExpectedCash
+		report.Cash.Variance = shift.Variance
+	}
+
+	report.CashMovements, err = r.cashMovements(shift.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func (r *reportRepository) cashMovements(shiftID uuid.UUID) ([]model.CashMovement, error) {
+	rows, err := r.db.Query(`
+		SELECT id, shift_id, type, amount, reason, created_by, created_at
+		FROM cash_movements
+		WHERE shift_id = $1
+		ORDER BY created_at`,
+		shiftID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query cash movements failed: %w", err)
+	}
+	defer rows.Close()
+
+	movements := make([]model.CashMovement, 0)
+	for rows.Next() {
+		var movement model.CashMovement
+		if err := rows.Scan(
+			&movement.ID,
+			&movement.ShiftID,
+			&movement.Type,
+			&movement.Amount,
+			&movement.Reason,
+			&movement.CreatedBy,
+			&movement.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan cash movement failed: %w", err)
+		}
+		movements = append(movements, movement)
+	}
+
+	return movements, rows.Err()
+}
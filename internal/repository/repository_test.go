@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/alert"
+	"github.com/gofrs/uuid/v5"
+)
+
+// newTestDB membuka database sqlite baru di direktori sementara dengan semua migrasi terpasang
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dialect, driver, dsn := database.ParseURL("sqlite://" + filepath.Join(t.TempDir(), "kasir.db"))
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, dialect)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	return db
+}
+
+func mustExec(t *testing.T, db *sql.DB, query string, args ...any) {
+	t.Helper()
+
+	if _, err := db.Exec(query, args...); err != nil {
+		t.Fatalf("exec %q: %v", query, err)
+	}
+}
+
+func newID(t *testing.T) uuid.UUID {
+	t.Helper()
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		t.Fatalf("generate id: %v", err)
+	}
+
+	return id
+}
+
+// seedCashier membuat user kasir dengan shift yang sudah dibuka
+func seedCashier(t *testing.T, db *sql.DB, username string) uuid.UUID {
+	t.Helper()
+
+	id := newID(t)
+	mustExec(t, db, "INSERT INTO users (id, username, name, password_hash, role) VALUES ($1,$2,$2,'x',$3)",
+		id, username, model.RoleCashier)
+	openTestShift(t, db, id, 0)
+
+	return id
+}
+
+func openTestShift(t *testing.T, db *sql.DB, cashierID uuid.UUID, openingFloat int64) uuid.UUID {
+	t.Helper()
+
+	shift, err := NewShiftRepository(db, database.SQLite).OpenShift(&model.Shift{
+		ID:           newID(t),
+		CashierID:    cashierID,
+		OpeningFloat: openingFloat,
+	})
+	if err != nil {
+		t.Fatalf("open shift: %v", err)
+	}
+
+	return shift.ID
+}
+
+// seedProduct membuat produk beserta kategorinya
+func seedProduct(t *testing.T, db *sql.DB, name string, price, stock int, taxExempt bool) uuid.UUID {
+	t.Helper()
+
+	categoryID := newID(t)
+	mustExec(t, db, "INSERT INTO categories (id, name, tax_exempt) VALUES ($1,$2,$3)", categoryID, name, taxExempt)
+
+	id := newID(t)
+	mustExec(t, db, "INSERT INTO product (id, name, price, stock, category_id) VALUES ($1,$2,$3,$4,$5)",
+		id, name, price, stock, categoryID)
+
+	return id
+}
+
+func productStock(t *testing.T, db *sql.DB, id uuid.UUID) int {
+	t.Helper()
+
+	var stock int
+	if err := db.QueryRow("SELECT stock FROM product WHERE id = $1", id).Scan(&stock); err != nil {
+		t.Fatalf("read stock: %v", err)
+	}
+
+	return stock
+}
+
+// checkout membuat transaksi tunai dengan PPN 11% eksklusif
+func checkout(t *testing.T, db *sql.DB, cashierID uuid.UUID, items ...dto.CheckoutItem) *model.Transaction {
+	t.Helper()
+
+	repo := NewTransactionRepository(db, database.SQLite, model.TaxConfig{Rate: 11}, alert.NewNotifier(""))
+	transaction, err := repo.CreateTransaction(&dto.CheckoutRequest{
+		Items:     items,
+		Payments:  []dto.CheckoutPayment{{Method: model.PaymentMethodCash, Amount: 1_000_000}},
+		CashierID: &cashierID,
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateTransaction() error = %v", err)
+	}
+
+	return transaction
+}
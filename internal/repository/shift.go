@@ -0,0 +1,383 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/gofrs/uuid/v5"
+)
+
+type ShiftRepository interface {
+	OpenShift(shift *model.Shift) (*model.Shift, error)
+	GetShifts(query *dto.ShiftQuery) ([]*model.Shift, int, error)
+	GetShiftByID(id string) (*model.Shift, error)
+	GetOpenShift(cashierID string) (*model.Shift, error)
+	AddCashMovement(shiftID string, movement *model.CashMovement) (*model.CashMovement, error)
+	CloseShift(id string, body *dto.CloseShiftRequest) (*model.Shift, error)
+}
+
+type shiftRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewShiftRepository(db *sql.DB, dialect database.Dialect) ShiftRepository {
+	return &shiftRepository{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// queryRower dipenuhi *sql.DB dan *sql.Tx
+type queryRower interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+type queryer interface {
+	queryRower
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// shiftColumns dipakai bersama JOIN users u untuk nama kasir
+const shiftColumns = `
+	s.id,
+	s.cashier_id,
+	COALESCE(u.name, ''),
+	COALESCE(s.terminal_id, ''),
+	s.status,
+	s.opening_float,
+	s.expected_cash,
+	s.counted_cash,
+	s.variance,
+	COALESCE(s.opening_note, ''),
+	COALESCE(s.closing_note, ''),
+	s.opened_at,
+	s.closed_at`
+
+func scanShift(row rowScanner) (*model.Shift, error) {
+	var shift model.Shift
+	err := row.Scan(
+		&shift.ID,
+		&shift.CashierID,
+		&shift.CashierName,
+		&shift.TerminalID,
+		&shift.Status,
+		&shift.OpeningFloat,
+		&shift.ExpectedCash,
+		&shift.CountedCash,
+		&shift.Variance,
+		&shift.OpeningNote,
+		&shift.ClosingNote,
+		&shift.OpenedAt,
+		&shift.ClosedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrShiftNotFound
+		}
+		return nil, err
+	}
+
+	return &shift, nil
+}
+
+func getShift(q queryRower, id string) (*model.Shift, error) {
+	return scanShift(q.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM shifts s JOIN users u ON u.id = s.cashier_id WHERE s.id = $1`, shiftColumns),
+		id,
+	))
+}
+
+// shiftCash menghitung kas yang seharusnya ada di laci. Penjualan tunai diambil dari
+// pembayaran cash dikurangi kembalian semua transaksi shift ini, termasuk yang nantinya
+// di-void. Void dan refund parsial dikurangkan dari shift tempat uangnya dikembalikan
+// (refunds.shift_id) sebesar bagian tunainya, sehingga angka shift yang sudah ditutup tetap
+func shiftCash(q queryRower, shift *model.Shift) (model.ShiftCashReport, error) {
+	cash := model.ShiftCashReport{OpeningFloat: shift.OpeningFloat}
+	err := q.QueryRow(fmt.Sprintf(`
+		SELECT
+			CAST(COALESCE((
+				SELECT SUM(tp.amount - tp.change_amount)
+				FROM transactions t
+				JOIN transaction_payments tp ON tp.transaction_id = t.id
+				WHERE t.shift_id = $1 AND tp.method = 'cash'
+			), 0) AS BIGINT),
+			CAST(COALESCE((
+				SELECT SUM(%s)
+				FROM refunds r
+				JOIN transactions t ON t.id = r.transaction_id
+				JOIN transaction_payments tp ON tp.transaction_id = t.id
+				WHERE r.shift_id = $1 AND tp.method = 'cash'
+			), 0) AS BIGINT),
+			CAST(COALESCE((SELECT SUM(amount) FROM cash_movements WHERE shift_id = $1 AND type = 'pay_in'), 0) AS BIGINT),
+			CAST(COALESCE((SELECT SUM(amount) FROM cash_movements WHERE shift_id = $1 AND type = 'pay_out'), 0) AS BIGINT)
+	`, shiftRefundShare("(tp.amount - tp.change_amount)")), shift.ID).Scan(&cash.CashSales, &cash.CashRefund, &cash.PayIn, &cash.PayOut)
+	if err != nil {
+		return cash, fmt.Errorf("query shift cash failed: %w", err)
+	}
+
+	cash.ExpectedCash = cash.OpeningFloat + cash.CashSales - cash.CashRefund + cash.PayIn - cash.PayOut
+	cash.CountedCash = shift.CountedCash
+	if shift.CountedCash != nil {
+		variance := *shift.CountedCash - cash.ExpectedCash
+		cash.Variance = &variance
+	}
+
+	return cash, nil
+}
+
+// openShiftID mencari shift terbuka milik kasir saat checkout. Barisnya dikunci supaya
+// shift tidak ditutup sebelum transaksi selesai. Kasir tanpa shift terbuka tidak boleh checkout
+// karena uang tunainya tidak akan masuk hitungan laci mana pun
+func openShiftID(tx *sql.Tx, dialect database.Dialect, cashierID uuid.UUID) (*uuid.UUID, error) {
+	var shiftID uuid.UUID
+	err := tx.QueryRow(
+		fmt.Sprintf(`SELECT id FROM shifts WHERE cashier_id = $1 AND status = $2 %s`, dialect.ForUpdate()),
+		cashierID,
+		model.ShiftStatusOpen,
+	).Scan(&shiftID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrNoOpenShift
+		}
+		return nil, fmt.Errorf("find open shift failed: %w", err)
+	}
+
+	return &shiftID, nil
+}
+
+// lockOpenShift mengunci shift dan memastikan statusnya masih open
+func (s *shiftRepository) lockOpenShift(tx *sql.Tx, id string) (*model.Shift, error) {
+	var status string
+	err := tx.QueryRow(
+		fmt.Sprintf(`SELECT status FROM shifts WHERE id = $1 %s`, s.dialect.ForUpdate()),
+		id,
+	).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrShiftNotFound
+		}
+		return nil, err
+	}
+
+	if status != model.ShiftStatusOpen {
+		return nil, utils.ErrShiftClosed
+	}
+
+	return getShift(tx, id)
+}
+
+func (s *shiftRepository) OpenShift(shift *model.Shift) (*model.Shift, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM shifts WHERE cashier_id = $1 AND status = $2)",
+		shift.CashierID,
+		model.ShiftStatusOpen,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, utils.ErrShiftAlreadyOpen
+	}
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`INSERT INTO shifts (id, cashier_id, terminal_id, status, opening_float, opening_note, opened_at)
+		VALUES ($1,$2,$3,$4,$5,$6,%s)`, s.dialect.Now()),
+		shift.ID,
+		shift.CashierID,
+		nullString(shift.TerminalID),
+		model.ShiftStatusOpen,
+		shift.OpeningFloat,
+		nullString(shift.OpeningNote),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	opened, err := getShift(tx, shift.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return opened, nil
+}
+
+func (s *shiftRepository) GetShifts(query *dto.ShiftQuery) ([]*model.Shift, int, error) {
+	var whereClause strings.Builder
+	var args []any
+	argsIdx := 1
+
+	whereClause.WriteString("WHERE 1=1 ")
+
+	if query.CashierID != "" {
+		fmt.Fprintf(&whereClause, " AND s.cashier_id = $%d", argsIdx)
+		args = append(args, query.CashierID)
+		argsIdx++
+	}
+
+	if query.Status != "" {
+		fmt.Fprintf(&whereClause, " AND s.status = $%d", argsIdx)
+		args = append(args, query.Status)
+		argsIdx++
+	}
+
+	rows, err := s.db.Query(fmt.Sprintf(`
+		SELECT %s
+		FROM shifts s
+		JOIN users u ON u.id = s.cashier_id
+		%s
+		ORDER BY s.opened_at DESC
+		LIMIT $%d OFFSET $%d`, shiftColumns, whereClause.String(), argsIdx, argsIdx+1),
+		append(args, query.Limit, query.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	shifts := make([]*model.Shift, 0)
+	for rows.Next() {
+		shift, err := scanShift(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		shifts = append(shifts, shift)
+	}
+
+	if rows.Err() != nil {
+		return nil, 0, rows.Err()
+	}
+
+	var total int
+	err = s.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM shifts s %s`, whereClause.String()),
+		args...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return shifts, total, nil
+}
+
+func (s *shiftRepository) GetShiftByID(id string) (*model.Shift, error) {
+	return getShift(s.db, id)
+}
+
+func (s *shiftRepository) GetOpenShift(cashierID string) (*model.Shift, error) {
+	shift, err := scanShift(s.db.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM shifts s JOIN users u ON u.id = s.cashier_id
+		WHERE s.cashier_id = $1 AND s.status = $2`, shiftColumns),
+		cashierID,
+		model.ShiftStatusOpen,
+	))
+	if errors.Is(err, utils.ErrShiftNotFound) {
+		return nil, fmt.Errorf("%w: no open shift for this cashier", utils.ErrShiftNotFound)
+	}
+
+	return shift, err
+}
+
+func (s *shiftRepository) AddCashMovement(shiftID string, movement *model.CashMovement) (*model.CashMovement, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	shift, err := s.lockOpenShift(tx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	movement.ShiftID = shift.ID
+	err = tx.QueryRow(
+		fmt.Sprintf(`INSERT INTO cash_movements (id, shift_id, type, amount, reason, created_by, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,%s)
+		RETURNING created_at`, s.dialect.Now()),
+		movement.ID,
+		movement.ShiftID,
+		movement.Type,
+		movement.Amount,
+		movement.Reason,
+		movement.CreatedBy,
+	).Scan(&movement.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+// CloseShift menghitung kas seharusnya, menyimpan hasil hitung fisik dan selisihnya
+func (s *shiftRepository) CloseShift(id string, body *dto.CloseShiftRequest) (*model.Shift, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	shift, err := s.lockOpenShift(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	shift.CountedCash = &body.CountedCash
+	cash, err := shiftCash(tx, shift)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`UPDATE shifts SET
+			status = $1,
+			expected_cash = $2,
+			counted_cash = $3,
+			variance = $4,
+			closing_note = $5,
+			closed_at = %s
+		WHERE id = $6`, s.dialect.Now()),
+		model.ShiftStatusClosed,
+		cash.ExpectedCash,
+		cash.CountedCash,
+		cash.Variance,
+		nullString(body.Note),
+		shift.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	closed, err := getShift(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return closed, nil
+}
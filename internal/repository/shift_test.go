@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+)
+
+func TestShiftCashRefundInLaterShift(t *testing.T) {
+	db := newTestDB(t)
+	cashierID := seedCashier(t, db, "kasir")
+	productID := seedProduct(t, db, "Roti", 10000, 5, false)
+
+	shifts := NewShiftRepository(db, database.SQLite)
+	refunds := NewRefundRepository(db, database.SQLite)
+	reports := NewReportRepository(db)
+
+	// 2 x 10.000 + PPN 11% = 22.200 dibayar tunai di shift pertama
+	transaction := checkout(t, db, cashierID, dto.CheckoutItem{ProductID: productID, Quantity: 2})
+	first := *transaction.ShiftID
+
+	closed, err := shifts.CloseShift(first.String(), &dto.CloseShiftRequest{CountedCash: 22200})
+	if err != nil {
+		t.Fatalf("CloseShift() error = %v", err)
+	}
+	if *closed.ExpectedCash != 22200 || *closed.Variance != 0 {
+		t.Fatalf("closed expected/variance = %d/%d, want 22200/0", *closed.ExpectedCash, *closed.Variance)
+	}
+
+	second := openTestShift(t, db, cashierID, 50000)
+
+	_, err = refunds.RefundTransaction(transaction.ID.String(), &dto.RefundRequest{
+		Reason: "rusak",
+		Items:  []dto.RefundItem{{TransactionDetailID: transaction.Details[0].ID, Quantity: 1}},
+		UserID: &cashierID,
+	})
+	if err != nil {
+		t.Fatalf("RefundTransaction() error = %v", err)
+	}
+	_, err = refunds.VoidTransaction(transaction.ID.String(), &dto.VoidRequest{Reason: "batal", UserID: &cashierID})
+	if err != nil {
+		t.Fatalf("VoidTransaction() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		shiftID      string
+		wantSales    int64
+		wantRefund   int64
+		wantExpected int64
+		wantTotal    int64
+	}{
+		{name: "shift penjualan tidak berubah", shiftID: first.String(), wantSales: 22200, wantRefund: 0, wantExpected: 22200, wantTotal: 22200},
+		{name: "refund dan void keluar dari shift berikutnya", shiftID: second.String(), wantSales: 0, wantRefund: 22200, wantExpected: 27800, wantTotal: -22200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := reports.ShiftReport(tt.shiftID)
+			if err != nil {
+				t.Fatalf("ShiftReport() error = %v", err)
+			}
+
+			cash := report.Cash
+			if cash.CashSales != tt.wantSales || cash.CashRefund != tt.wantRefund || cash.ExpectedCash != tt.wantExpected {
+				t.Errorf("cash sales/refund/expected = %d/%d/%d, want %d/%d/%d",
+					cash.CashSales, cash.CashRefund, cash.ExpectedCash, tt.wantSales, tt.wantRefund, tt.wantExpected)
+			}
+			if report.TotalSales != tt.wantTotal {
+				t.Errorf("TotalSales = %d, want %d", report.TotalSales, tt.wantTotal)
+			}
+
+			var cashMethod int64
+			for _, m := range report.PaymentMethods {
+				if m.Method == model.PaymentMethodCash {
+					cashMethod = m.TotalAmount
+				}
+			}
+			if cashMethod != tt.wantSales-tt.wantRefund {
+				t.Errorf("cash payment method = %d, want %d", cashMethod, tt.wantSales-tt.wantRefund)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/gofrs/uuid/v5"
+)
+
+// applyStockMovement adalah satu-satunya jalan untuk mengubah product.stock: stok dikunci,
+// ditambah sebesar movement.Quantity lalu movement dicatat beserta stok akhirnya.
+// ID dan CreatedAt movement diisi di sini. Produk induk varian tidak punya stok sendiri
+func applyStockMovement(tx *sql.Tx, dialect database.Dialect, movement *model.StockMovement) error {
+	var stock int
+	var hasVariants bool
+	err := tx.QueryRow(
+		fmt.Sprintf("SELECT stock, has_variants FROM product WHERE id = $1 %s", dialect.ForUpdate()),
+		movement.ProductID,
+	).Scan(&stock, &hasVariants)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.ErrProductNotFound
+		}
+		return err
+	}
+
+	if hasVariants {
+		return fmt.Errorf("%w: %s", utils.ErrProductHasVariants, movement.ProductID)
+	}
+
+	if stock+movement.Quantity < 0 {
+		return &apperror.InsufficientStockError{
+			ProductID: movement.ProductID,
+			Available: stock,
+			Requested: -movement.Quantity,
+		}
+	}
+
+	err = tx.QueryRow(
+		"UPDATE product SET stock = stock + $1 WHERE id = $2 RETURNING stock",
+		movement.Quantity,
+		movement.ProductID,
+	).Scan(&movement.StockAfter)
+	if err != nil {
+		return fmt.Errorf("failed to update stock for product %s: %w", movement.ProductID, err)
+	}
+
+	movement.ID, err = uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("generate stock movement id failed: %w", err)
+	}
+
+	err = tx.QueryRow(
+		fmt.Sprintf(`INSERT INTO stock_movements (id, product_id, type, quantity, stock_after, reason, reference_id, created_by, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,%s)
+		RETURNING created_at`, dialect.Now()),
+		movement.ID,
+		movement.ProductID,
+		movement.Type,
+		movement.Quantity,
+		movement.StockAfter,
+		movement.Reason,
+		movement.ReferenceID,
+		movement.CreatedBy,
+	).Scan(&movement.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("record stock movement failed: %w", err)
+	}
+
+	return nil
+}
+
+func (p *productRepo) GetStockMovements(productID string, query *dto.StockMovementQuery) ([]*model.StockMovement, int, error) {
+	var whereClause strings.Builder
+	args := []any{productID}
+	argsIdx := 2
+
+	whereClause.WriteString("WHERE m.product_id = $1 ")
+
+	if query.Type != "" {
+		fmt.Fprintf(&whereClause, " AND m.type = $%d", argsIdx)
+		args = append(args, query.Type)
+		argsIdx++
+	}
+
+	rows, err := p.db.Query(fmt.Sprintf(`
+		SELECT
+			m.id,
+			m.product_id,
+			m.type,
+			m.quantity,
+			m.stock_after,
+			m.reason,
+			m.reference_id,
+			m.created_by,
+			COALESCE(u.name, ''),
+			m.created_at
+		FROM stock_movements m
+		LEFT JOIN users u ON u.id = m.created_by
+		%s
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT $%d OFFSET $%d`, whereClause.String(), argsIdx, argsIdx+1),
+		append(args, query.Limit, query.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	movements := make([]*model.StockMovement, 0)
+	for rows.Next() {
+		var movement model.StockMovement
+		if err := rows.Scan(
+			&movement.ID,
+			&movement.ProductID,
+			&movement.Type,
+			&movement.Quantity,
+			&movement.StockAfter,
+			&movement.Reason,
+			&movement.ReferenceID,
+			&movement.CreatedBy,
+			&movement.CreatedByName,
+			&movement.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		movements = append(movements, &movement)
+	}
+
+	if rows.Err() != nil {
+		return nil, 0, rows.Err()
+	}
+
+	var total int
+	err = p.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM stock_movements m %s`, whereClause.String()),
+		args...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return movements, total, nil
+}
+
+func (p *productRepo) CreateStockMovement(movement *model.StockMovement) (*model.StockMovement, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := applyStockMovement(tx, p.dialect, movement); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+// lowStockAlert mengembalikan alert jika movement membuat stok turun dari di atas reorder
+// point menjadi sama atau di bawahnya. Produk yang memang sudah menipis tidak dialert ulang
+func lowStockAlert(product *model.Product, movement *model.StockMovement) *model.LowStockAlert {
+	if product.ReorderPoint == nil {
+		return nil
+	}
+
+	before := movement.StockAfter - movement.Quantity
+	if before <= *product.ReorderPoint || movement.StockAfter > *product.ReorderPoint {
+		return nil
+	}
+
+	return &model.LowStockAlert{
+		ProductID:       movement.ProductID,
+		ProductName:     product.Name,
+		Stock:           movement.StockAfter,
+		ReorderPoint:    *product.ReorderPoint,
+		ReorderQuantity: product.ReorderQuantity,
+		ReferenceID:     movement.ReferenceID,
+		OccurredAt:      movement.CreatedAt,
+	}
+}
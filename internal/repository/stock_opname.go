@@ -0,0 +1,543 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/gofrs/uuid/v5"
+)
+
+type StockOpnameRepository interface {
+	CreateStockOpname(opname *model.StockOpname) (*model.StockOpname, error)
+	GetStockOpnames(query *dto.StockOpnameQuery) ([]*model.StockOpname, int, error)
+	GetStockOpnameByID(id string) (*model.StockOpname, error)
+	SubmitCounts(id string, body *dto.StockOpnameCountRequest) (*model.StockOpname, error)
+	PostStockOpname(id string, userID *uuid.UUID) (*model.StockOpname, error)
+	CancelStockOpname(id string, userID *uuid.UUID) (*model.StockOpname, error)
+}
+
+type stockOpnameRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewStockOpnameRepository(db *sql.DB, dialect database.Dialect) StockOpnameRepository {
+	return &stockOpnameRepository{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+const stockOpnameColumns = `
+	o.id,
+	o.category_id,
+	COALESCE(c.name, ''),
+	o.status,
+	COALESCE(o.note, ''),
+	o.created_by,
+	o.created_at,
+	o.closed_by,
+	o.closed_at,
+	(SELECT COUNT(*) FROM stock_opname_items i WHERE i.opname_id = o.id),
+	(SELECT COUNT(i.counted_quantity) FROM stock_opname_items i WHERE i.opname_id = o.id)`
+
+func scanStockOpname(row rowScanner) (*model.StockOpname, error) {
+	var opname model.StockOpname
+	err := row.Scan(
+		&opname.ID,
+		&opname.CategoryID,
+		&opname.CategoryName,
+		&opname.Status,
+		&opname.Note,
+		&opname.CreatedBy,
+		&opname.CreatedAt,
+		&opname.ClosedBy,
+		&opname.ClosedAt,
+		&opname.ItemCount,
+		&opname.CountedCount,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrStockOpnameNotFound
+		}
+		return nil, err
+	}
+
+	return &opname, nil
+}
+
+// getStockOpname mengambil sesi beserta item dan ringkasan selisihnya
+func getStockOpname(q queryer, id string) (*model.StockOpname, error) {
+	opname, err := scanStockOpname(q.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM stock_opnames o LEFT JOIN categories c ON c.id = o.category_id WHERE o.id = $1`, stockOpnameColumns),
+		id,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(`
+		SELECT
+			i.product_id,
+			p.name,
+			p.price,
+			i.start_stock,
+			i.system_stock,
+			i.counted_quantity,
+			p.stock,
+			i.counted_by,
+			COALESCE(u.name, ''),
+			i.counted_at,
+			i.adjustment
+		FROM stock_opname_items i
+		JOIN product p ON p.id = i.product_id
+		LEFT JOIN users u ON u.id = i.counted_by
+		WHERE i.opname_id = $1
+		ORDER BY p.name`,
+		opname.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := model.StockOpnameTotal{}
+	opname.Items = make([]model.StockOpnameItem, 0)
+	for rows.Next() {
+		var item model.StockOpnameItem
+		if err := rows.Scan(
+			&item.ProductID,
+			&item.ProductName,
+			&item.Price,
+			&item.StartStock,
+			&item.SystemStock,
+			&item.CountedQuantity,
+			&item.CurrentStock,
+			&item.CountedBy,
+			&item.CountedByName,
+			&item.CountedAt,
+			&item.Adjustment,
+		); err != nil {
+			return nil, err
+		}
+
+		if item.CountedQuantity != nil && item.SystemStock != nil {
+			variance := *item.CountedQuantity - *item.SystemStock
+			item.Variance = &variance
+
+			if variance > 0 {
+				summary.SurplusQuantity += variance
+			} else {
+				summary.ShortageQuantity -= variance
+			}
+			summary.VarianceValue += int64(variance) * int64(item.Price)
+		}
+
+		opname.Items = append(opname.Items, item)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	if err := attachStockOpnameCounts(q, opname); err != nil {
+		return nil, err
+	}
+
+	opname.Summary = &summary
+	return opname, nil
+}
+
+// attachStockOpnameCounts mengisi rincian hitungan per penghitung untuk setiap item
+func attachStockOpnameCounts(q queryer, opname *model.StockOpname) error {
+	rows, err := q.Query(`
+		SELECT sc.product_id, sc.counted_by, COALESCE(u.name, ''), sc.counted_quantity, sc.counted_at
+		FROM stock_opname_counts sc
+		LEFT JOIN users u ON u.id = sc.counted_by
+		WHERE sc.opname_id = $1
+		ORDER BY sc.counted_at`,
+		opname.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("query stock opname counts failed: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID][]model.StockOpnameCount)
+	for rows.Next() {
+		var productID uuid.UUID
+		var count model.StockOpnameCount
+		if err := rows.Scan(&productID, &count.CountedBy, &count.CountedByName, &count.CountedQuantity, &count.CountedAt); err != nil {
+			return fmt.Errorf("scan stock opname count failed: %w", err)
+		}
+		counts[productID] = append(counts[productID], count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range opname.Items {
+		opname.Items[i].Counts = counts[opname.Items[i].ProductID]
+	}
+
+	return nil
+}
+
+// lockOpenStockOpname mengunci sesi supaya hitungan tidak masuk setelah sesi diposting
+func (s *stockOpnameRepository) lockOpenStockOpname(tx *sql.Tx, id string) error {
+	var status string
+	err := tx.QueryRow(
+		fmt.Sprintf(`SELECT status FROM stock_opnames WHERE id = $1 %s`, s.dialect.ForUpdate()),
+		id,
+	).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.ErrStockOpnameNotFound
+		}
+		return err
+	}
+
+	if status != model.StockOpnameStatusOpen {
+		return utils.ErrStockOpnameNotOpen
+	}
+
+	return nil
+}
+
+// CreateStockOpname membuat sesi dan item untuk semua produk dalam cakupan dengan stok awal
+// saat ini. Satu produk tidak boleh masuk dua sesi yang masih open
+func (s *stockOpnameRepository) CreateStockOpname(opname *model.StockOpname) (*model.StockOpname, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	scope := "1=1"
+	args := []any{}
+	if opname.CategoryID != nil {
+		var exists bool
+		err = tx.QueryRow("SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1 AND deleted_at IS NULL)", opname.CategoryID).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, utils.ErrCategoryNotFound
+		}
+
+		scope = "p.category_id = $1"
+		args = append(args, opname.CategoryID)
+	}
+
+	var overlap bool
+	err = tx.QueryRow(fmt.Sprintf(`
+		SELECT EXISTS(
+			SELECT 1
+			FROM stock_opname_items i
+			JOIN stock_opnames o ON o.id = i.opname_id
+			JOIN product p ON p.id = i.product_id
+			WHERE o.status = 'open' AND %s
+		)`, scope),
+		args...,
+	).Scan(&overlap)
+	if err != nil {
+		return nil, err
+	}
+
+	if overlap {
+		return nil, utils.ErrStockOpnameOverlap
+	}
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`INSERT INTO stock_opnames (id, category_id, status, note, created_by, created_at)
+		VALUES ($1,$2,$3,$4,$5,%s)`, s.dialect.Now()),
+		opname.ID,
+		opname.CategoryID,
+		model.StockOpnameStatusOpen,
+		nullString(opname.Note),
+		opname.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(fmt.Sprintf(`
+		INSERT INTO stock_opname_items (opname_id, product_id, start_stock)
+		SELECT o.id, p.id, p.stock
+		FROM product p
+		JOIN stock_opnames o ON o.id = $%d
+		WHERE p.deleted_at IS NULL AND p.has_variants = FALSE AND %s`, len(args)+1, scope),
+		append(args, opname.ID)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create stock opname items failed: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if affected == 0 {
+		return nil, utils.ErrStockOpnameEmpty
+	}
+
+	created, err := getStockOpname(tx, opname.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *stockOpnameRepository) GetStockOpnames(query *dto.StockOpnameQuery) ([]*model.StockOpname, int, error) {
+	var whereClause strings.Builder
+	var args []any
+	argsIdx := 1
+
+	whereClause.WriteString("WHERE 1=1 ")
+
+	if query.Status != "" {
+		fmt.Fprintf(&whereClause, " AND o.status = $%d", argsIdx)
+		args = append(args, query.Status)
+		argsIdx++
+	}
+
+	rows, err := s.db.Query(fmt.Sprintf(`
+		SELECT %s
+		FROM stock_opnames o
+		LEFT JOIN categories c ON c.id = o.category_id
+		%s
+		ORDER BY o.created_at DESC
+		LIMIT $%d OFFSET $%d`, stockOpnameColumns, whereClause.String(), argsIdx, argsIdx+1),
+		append(args, query.Limit, query.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	opnames := make([]*model.StockOpname, 0)
+	for rows.Next() {
+		opname, err := scanStockOpname(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		opnames = append(opnames, opname)
+	}
+
+	if rows.Err() != nil {
+		return nil, 0, rows.Err()
+	}
+
+	var total int
+	err = s.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM stock_opnames o %s`, whereClause.String()),
+		args...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return opnames, total, nil
+}
+
+func (s *stockOpnameRepository) GetStockOpnameByID(id string) (*model.StockOpname, error) {
+	return getStockOpname(s.db, id)
+}
+
+// SubmitCounts menyimpan hasil hitung penghitung yang login. Stok sistem diambil saat produk
+// pertama kali dihitung: penjualan sebelumnya sudah tercermin di stok sistem, penjualan
+// setelahnya tidak mengubah selisih. Hitungan semua penghitung dijumlahkan per produk
+func (s *stockOpnameRepository) SubmitCounts(id string, body *dto.StockOpnameCountRequest) (*model.StockOpname, error) {
+	if body.UserID == nil {
+		return nil, utils.ErrUnauthenticated
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.lockOpenStockOpname(tx, id); err != nil {
+		return nil, err
+	}
+
+	for _, item := range body.Items {
+		result, err := tx.Exec(
+			`UPDATE stock_opname_items SET system_stock = COALESCE(system_stock, (SELECT stock FROM product WHERE id = $1))
+			WHERE opname_id = $2 AND product_id = $1`,
+			item.ProductID,
+			id,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("submit count for product %s failed: %w", item.ProductID, err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		if affected == 0 {
+			return nil, fmt.Errorf("%w: %s", utils.ErrInvalidStockOpnameItem, item.ProductID)
+		}
+
+		_, err = tx.Exec(
+			fmt.Sprintf(`INSERT INTO stock_opname_counts (opname_id, product_id, counted_by, counted_quantity, counted_at)
+			VALUES ($1,$2,$3,$4,%s)
+			ON CONFLICT (opname_id, product_id, counted_by)
+			DO UPDATE SET counted_quantity = excluded.counted_quantity, counted_at = excluded.counted_at`, s.dialect.Now()),
+			id,
+			item.ProductID,
+			body.UserID,
+			item.CountedQuantity,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("submit count for product %s failed: %w", item.ProductID, err)
+		}
+
+		_, err = tx.Exec(
+			fmt.Sprintf(`UPDATE stock_opname_items SET
+				counted_quantity = (SELECT SUM(counted_quantity) FROM stock_opname_counts WHERE opname_id = $1 AND product_id = $2),
+				counted_by = $3,
+				counted_at = %s
+			WHERE opname_id = $1 AND product_id = $2`, s.dialect.Now()),
+			id,
+			item.ProductID,
+			body.UserID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("submit count for product %s failed: %w", item.ProductID, err)
+		}
+	}
+
+	opname, err := getStockOpname(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return opname, nil
+}
+
+// PostStockOpname membuat movement opname untuk setiap item yang sudah dihitung dan punya selisih,
+// semuanya dalam satu transaksi. Item yang belum dihitung dilewati
+func (s *stockOpnameRepository) PostStockOpname(id string, userID *uuid.UUID) (*model.StockOpname, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.lockOpenStockOpname(tx, id); err != nil {
+		return nil, err
+	}
+
+	opname, err := getStockOpname(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if opname.CountedCount == 0 {
+		return nil, fmt.Errorf("%w: no product has been counted", utils.ErrStockOpnameEmpty)
+	}
+
+	for _, item := range opname.Items {
+		if item.Variance == nil {
+			continue
+		}
+
+		if *item.Variance != 0 {
+			err := applyStockMovement(tx, s.dialect, &model.StockMovement{
+				ProductID:   item.ProductID,
+				Type:        model.StockMovementOpname,
+				Quantity:    *item.Variance,
+				Reason:      "stock opname",
+				ReferenceID: &opname.ID,
+				CreatedBy:   userID,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		_, err := tx.Exec(
+			"UPDATE stock_opname_items SET adjustment = $1 WHERE opname_id = $2 AND product_id = $3",
+			*item.Variance,
+			opname.ID,
+			item.ProductID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.close(tx, opname.ID, model.StockOpnameStatusPosted, userID); err != nil {
+		return nil, err
+	}
+
+	posted, err := getStockOpname(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return posted, nil
+}
+
+func (s *stockOpnameRepository) CancelStockOpname(id string, userID *uuid.UUID) (*model.StockOpname, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.lockOpenStockOpname(tx, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.close(tx, id, model.StockOpnameStatusCancelled, userID); err != nil {
+		return nil, err
+	}
+
+	cancelled, err := getStockOpname(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return cancelled, nil
+}
+
+func (s *stockOpnameRepository) close(tx *sql.Tx, id any, status string, userID *uuid.UUID) error {
+	_, err := tx.Exec(
+		fmt.Sprintf(`UPDATE stock_opnames SET status = $1, closed_by = $2, closed_at = %s WHERE id = $3`, s.dialect.Now()),
+		status,
+		userID,
+		id,
+	)
+	return err
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+)
+
+type SupplierRepository interface {
+	GetSuppliers(query *dto.SupplierQuery) ([]*model.Supplier, int, error)
+	GetSupplierByID(id string) (*model.Supplier, error)
+	CreateSupplier(body *model.Supplier) (*model.Supplier, error)
+	UpdateSupplierByID(id string, body *model.Supplier) (*model.Supplier, error)
+	DeleteSupplierByID(id string) error
+}
+
+type supplierRepo struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewSupplierRepository(db *sql.DB, dialect database.Dialect) SupplierRepository {
+	return &supplierRepo{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+const supplierColumns = `
+	id,
+	name,
+	COALESCE(contact_name, ''),
+	COALESCE(phone, ''),
+	COALESCE(email, ''),
+	COALESCE(address, ''),
+	created_at,
+	updated_at`
+
+func scanSupplier(row rowScanner) (*model.Supplier, error) {
+	var supplier model.Supplier
+	err := row.Scan(
+		&supplier.ID,
+		&supplier.Name,
+		&supplier.ContactName,
+		&supplier.Phone,
+		&supplier.Email,
+		&supplier.Address,
+		&supplier.CreatedAt,
+		&supplier.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrSupplierNotFound
+		}
+		return nil, err
+	}
+
+	return &supplier, nil
+}
+
+func (s *supplierRepo) GetSuppliers(query *dto.SupplierQuery) ([]*model.Supplier, int, error) {
+	var whereClause strings.Builder
+	var args []any
+	argsIdx := 1
+
+	whereClause.WriteString("WHERE 1=1 ")
+
+	if query.Name != "" {
+		fmt.Fprintf(&whereClause, " AND LOWER(name) LIKE LOWER($%d)", argsIdx)
+		args = append(args, "%"+query.Name+"%")
+		argsIdx++
+	}
+
+	rows, err := s.db.Query(fmt.Sprintf(`
+		SELECT %s
+		FROM suppliers
+		%s
+		ORDER BY name
+		LIMIT $%d OFFSET $%d`, supplierColumns, whereClause.String(), argsIdx, argsIdx+1),
+		append(args, query.Limit, query.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	suppliers := make([]*model.Supplier, 0)
+	for rows.Next() {
+		supplier, err := scanSupplier(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		suppliers = append(suppliers, supplier)
+	}
+
+	if rows.Err() != nil {
+		return nil, 0, rows.Err()
+	}
+
+	var total int
+	err = s.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM suppliers %s`, whereClause.String()),
+		args...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return suppliers, total, nil
+}
+
+func (s *supplierRepo) GetSupplierByID(id string) (*model.Supplier, error) {
+	return scanSupplier(s.db.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM suppliers WHERE id = $1`, supplierColumns),
+		id,
+	))
+}
+
+// ensureNameAvailable memastikan nama supplier belum dipakai supplier lain
+func (s *supplierRepo) ensureNameAvailable(name, exceptID string) error {
+	var exists bool
+	err := s.db.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM suppliers WHERE LOWER(name) = LOWER($1) AND id <> $2)",
+		name,
+		exceptID,
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return utils.ErrSupplierNameTaken
+	}
+
+	return nil
+}
+
+func (s *supplierRepo) CreateSupplier(body *model.Supplier) (*model.Supplier, error) {
+	if err := s.ensureNameAvailable(body.Name, body.ID.String()); err != nil {
+		return nil, err
+	}
+
+	err := s.db.QueryRow(
+		fmt.Sprintf(`INSERT INTO suppliers (id, name, contact_name, phone, email, address, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,%[1]s,%[1]s)
+		RETURNING created_at, updated_at`, s.dialect.Now()),
+		body.ID,
+		body.Name,
+		nullString(body.ContactName),
+		nullString(body.Phone),
+		nullString(body.Email),
+		nullString(body.Address),
+	).Scan(&body.CreatedAt, &body.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+func (s *supplierRepo) UpdateSupplierByID(id string, body *model.Supplier) (*model.Supplier, error) {
+	if err := s.ensureNameAvailable(body.Name, id); err != nil {
+		return nil, err
+	}
+
+	err := s.db.QueryRow(
+		fmt.Sprintf(`UPDATE suppliers SET name = $1, contact_name = $2, phone = $3, email = $4, address = $5, updated_at = %s
+		WHERE id = $6
+		RETURNING id, created_at, updated_at`, s.dialect.Now()),
+		body.Name,
+		nullString(body.ContactName),
+		nullString(body.Phone),
+		nullString(body.Email),
+		nullString(body.Address),
+		id,
+	).Scan(&body.ID, &body.CreatedAt, &body.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrSupplierNotFound
+		}
+		return nil, err
+	}
+
+	return body, nil
+}
+
+func (s *supplierRepo) DeleteSupplierByID(id string) error {
+	var used bool
+	err := s.db.QueryRow("SELECT EXISTS(SELECT 1 FROM purchase_orders WHERE supplier_id = $1)", id).Scan(&used)
+	if err != nil {
+		return err
+	}
+
+	if used {
+		return utils.ErrSupplierInUse
+	}
+
+	result, err := s.db.Exec(`DELETE FROM suppliers WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return utils.ErrSupplierNotFound
+	}
+
+	return nil
+}
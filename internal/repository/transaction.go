@@ -5,29 +5,52 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/Muh-Sidik/kasir-api/database"
 	"github.com/Muh-Sidik/kasir-api/internal/model"
 	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/alert"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/apperror"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
 	"github.com/gofrs/uuid/v5"
 )
 
 type TransactionRepository interface {
-	CreateTransaction(items []dto.CheckoutItem) (*model.Transaction, error)
+	CreateTransaction(body *dto.CheckoutRequest, idempotencyKey *model.IdempotencyKey) (*model.Transaction, error)
+	FindIdempotencyKey(cashierID uuid.UUID, key string) (*model.IdempotencyKey, error)
+	GetTransactions(query *dto.TransactionQuery) ([]*model.Transaction, int, error)
+	GetTransactionByID(id string) (*model.Transaction, error)
 }
 
 type transactionRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	dialect  database.Dialect
+	tax      model.TaxConfig
+	notifier alert.Notifier
 }
 
-func NewTransactionRepository(db *sql.DB) TransactionRepository {
+func NewTransactionRepository(db *sql.DB, dialect database.Dialect, tax model.TaxConfig, notifier alert.Notifier) TransactionRepository {
 	return &transactionRepository{
-		db: db,
+		db:       db,
+		dialect:  dialect,
+		tax:      tax,
+		notifier: notifier,
 	}
 }
 
-func (t *transactionRepository) CreateTransaction(items []dto.CheckoutItem) (*model.Transaction, error) {
+// checkoutProduct adalah produk yang dikunci saat checkout beserta status pajak kategorinya
+type checkoutProduct struct {
+	model.Product
+	SKU          *string
+	CategoryName string
+	TaxExempt    bool
+}
+
+func (t *transactionRepository) CreateTransaction(body *dto.CheckoutRequest, idempotencyKey *model.IdempotencyKey) (*model.Transaction, error) {
+	items := body.Items
 	if len(items) == 0 {
-		return nil, errors.New("items cannot be empty")
+		return nil, utils.ErrEmptyCheckout
 	}
 
 	tx, err := t.db.Begin()
@@ -36,65 +59,264 @@ func (t *transactionRepository) CreateTransaction(items []dto.CheckoutItem) (*mo
 	}
 	defer tx.Rollback()
 
+	if idempotencyKey != nil {
+		reserved, err := reserveIdempotencyKey(tx, t.dialect, idempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if !reserved {
+			return nil, utils.ErrIdempotencyKeyExists
+		}
+	}
+
 	producttock, err := t.validateAndStockLock(tx, items)
 	if err != nil {
 		return nil, err
 	}
 
+	modifiers, err := resolveModifiers(tx, producttock, items)
+	if err != nil {
+		return nil, err
+	}
+
 	transactionID, err := uuid.NewV7()
 	if err != nil {
 		return nil, fmt.Errorf("failed generate id: %w", err)
 	}
-	totalAmount := int64(0)
+	now := time.Now()
+	productIDs := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+
+	itemPromotions, err := findItemPromotions(tx, productIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// promo item dihitung dari harga produk saja, tambahan modifier tidak ikut didiskon
+	itemDiscounts := groupItemDiscounts(items, producttock, itemPromotions)
+
 	details := make([]model.TransactionDetail, 0, len(items))
+	var lowStock []model.LowStockAlert
 	for i, item := range items {
-		subTotal := int64(producttock[i].Price) * int64(item.Quantity)
-		totalAmount += subTotal
+		var modifierPrice int64
+		for _, modifier := range modifiers[i] {
+			modifierPrice += modifier.PriceDelta
+		}
+		unitPrice := int64(producttock[i].Price) + modifierPrice
+		subTotal := unitPrice * int64(item.Quantity)
+		promotion, discount := itemDiscounts[i].promotion, itemDiscounts[i].amount
 
-		_, err = tx.Exec(
-			"UPDATE product SET stock = stock - $1 WHERE id = $2",
-			item.Quantity,
-			item.ProductID,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to update stock for product %s: %w", item.ProductID, err)
+		movement := &model.StockMovement{
+			ProductID:   item.ProductID,
+			Type:        model.StockMovementSale,
+			Quantity:    -item.Quantity,
+			ReferenceID: &transactionID,
+			CreatedBy:   body.CashierID,
+		}
+		if err := applyStockMovement(tx, t.dialect, movement); err != nil {
+			return nil, err
+		}
+		if a := lowStockAlert(&producttock[i].Product, movement); a != nil {
+			lowStock = append(lowStock, *a)
 		}
 		detailID, err := uuid.NewV7()
 		if err != nil {
 			return nil, fmt.Errorf("generate detail id failed: %w", err)
 		}
 
-		details = append(details, model.TransactionDetail{
+		detail := model.TransactionDetail{
 			ID:            detailID,
 			ProductID:     item.ProductID,
 			TransactionID: transactionID,
 			ProductName:   producttock[i].Name,
+			SKU:           producttock[i].SKU,
+			UnitPrice:     unitPrice,
+			CategoryID:    &producttock[i].CategoryID,
+			CategoryName:  producttock[i].CategoryName,
 			Quantity:      item.Quantity,
-			Subtotal:      subTotal,
-		})
+			UnitCost:      producttock[i].Cost,
+			Discount:      discount,
+			Subtotal:      subTotal - discount,
+			Modifiers:     modifiers[i],
+		}
+		if promotion != nil {
+			detail.PromotionID = &promotion.ID
+		}
+
+		details = append(details, detail)
+	}
+
+	var cartPromotion *model.Promotion
+	if body.PromoCode != "" {
+		cartPromotion, err = findPromotionByCode(tx, body.PromoCode, now)
+		if err != nil {
+			return nil, err
+		}
+
+		var subTotal int64
+		for _, d := range details {
+			subTotal += d.Subtotal
+		}
+
+		cartDiscount := cartPromotion.CartDiscount(subTotal)
+		if cartDiscount == 0 {
+			return nil, fmt.Errorf("%w: minimum purchase %d not reached", utils.ErrInvalidPromoCode, cartPromotion.MinPurchase)
+		}
+
+		allocateCartDiscount(details, cartDiscount)
+	}
+
+	subTotal := int64(0)
+	taxableSubTotal := int64(0)
+	discountAmount := int64(0)
+	for i, d := range details {
+		subTotal += d.Subtotal
+		discountAmount += d.Discount
+		if !producttock[i].TaxExempt {
+			taxableSubTotal += d.Subtotal
+		}
 	}
 
-	_, err = tx.Exec("INSERT INTO transactions (id, total_amount, created_at) VALUES ($1,$2,NOW())", transactionID, totalAmount)
+	summary := t.tax.Calculate(subTotal, taxableSubTotal)
+	totalAmount := summary.TotalAmount
+
+	payments, paidAmount, changeAmount, err := settlePayments(transactionID, totalAmount, body.Payments)
 	if err != nil {
 		return nil, err
 	}
 
+	var promotionID *uuid.UUID
+	if cartPromotion != nil {
+		promotionID = &cartPromotion.ID
+	}
+
+	if body.CashierID == nil {
+		return nil, utils.ErrNoOpenShift
+	}
+
+	shiftID, err := openShiftID(tx, t.dialect, *body.CashierID)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdAt time.Time
+	err = tx.QueryRow(
+		fmt.Sprintf(`INSERT INTO transactions (
+			id, subtotal, service_charge, tax_base, tax_amount, tax_inclusive, total_amount,
+			discount_amount, promotion_id, promo_code, paid_amount, change_amount, status,
+			cashier_id, terminal_id, shift_id, created_at
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,%s)
+		RETURNING created_at`, t.dialect.Now()),
+		transactionID,
+		summary.Subtotal,
+		summary.ServiceCharge,
+		summary.TaxBase,
+		summary.TaxAmount,
+		t.tax.Inclusive,
+		totalAmount,
+		discountAmount,
+		promotionID,
+		nullString(body.PromoCode),
+		paidAmount,
+		changeAmount,
+		model.TransactionStatusCompleted,
+		body.CashierID,
+		nullString(body.TerminalID),
+		shiftID,
+	).Scan(&createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// detail dan pembayaran memakai waktu transaksi supaya response (dan response
+	// idempotent yang disimpan) sama dengan isi database
+	for i := range details {
+		details[i].CreatedAt = createdAt
+	}
+	for i := range payments {
+		payments[i].CreatedAt = createdAt
+	}
+
 	if err := t.bulkInsertDetails(tx, details); err != nil {
 		return nil, err
 	}
 
+	if err := insertDetailModifiers(tx, details); err != nil {
+		return nil, err
+	}
+
+	if err := bulkInsertPayments(tx, payments); err != nil {
+		return nil, err
+	}
+
+	transaction := &model.Transaction{
+		ID:             transactionID,
+		Subtotal:       summary.Subtotal,
+		ServiceCharge:  summary.ServiceCharge,
+		TaxBase:        summary.TaxBase,
+		TaxAmount:      summary.TaxAmount,
+		TaxInclusive:   t.tax.Inclusive,
+		TotalAmount:    totalAmount,
+		DiscountAmount: discountAmount,
+		PromotionID:    promotionID,
+		PromoCode:      body.PromoCode,
+		PaidAmount:     paidAmount,
+		ChangeAmount:   changeAmount,
+		Status:         model.TransactionStatusCompleted,
+		CashierID:      body.CashierID,
+		TerminalID:     body.TerminalID,
+		ShiftID:        shiftID,
+		CreatedAt:      createdAt,
+		Details:        details,
+		Payments:       payments,
+	}
+
+	if idempotencyKey != nil {
+		if err := completeIdempotencyKey(tx, idempotencyKey, transaction); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
-	return &model.Transaction{
-		ID:          transactionID,
-		TotalAmount: totalAmount,
-		Details:     details,
-	}, nil
+	// alert dikirim setelah commit supaya tidak ada alert untuk checkout yang gagal
+	t.notifier.LowStock(lowStock)
+
+	return transaction, nil
+}
+
+// resolveBarcodes mengisi product_id untuk item yang dikirim lewat barcode hasil scan,
+// barcode milik produk yang diarsipkan dianggap tidak ada seperti GetProductByBarcode
+func resolveBarcodes(tx *sql.Tx, items []dto.CheckoutItem) error {
+	for i, item := range items {
+		if !item.ProductID.IsNil() || item.Barcode == "" {
+			continue
+		}
+
+		err := tx.QueryRow(
+			`SELECT b.product_id FROM product_barcodes b JOIN product p ON p.id = b.product_id WHERE b.code = $1 AND p.deleted_at IS NULL`,
+			item.Barcode,
+		).Scan(&items[i].ProductID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: %s", utils.ErrBarcodeNotFound, item.Barcode)
+			}
+			return fmt.Errorf("query barcode failed: %w", err)
+		}
+	}
+
+	return nil
 }
 
-func (t *transactionRepository) validateAndStockLock(tx *sql.Tx, items []dto.CheckoutItem) ([]model.Product, error) {
+func (t *transactionRepository) validateAndStockLock(tx *sql.Tx, items []dto.CheckoutItem) ([]checkoutProduct, error) {
+	if err := resolveBarcodes(tx, items); err != nil {
+		return nil, err
+	}
+
 	productIDs := make([]any, len(items))
 	placeholders := make([]string, len(items))
 	for i, item := range items {
@@ -104,11 +326,13 @@ func (t *transactionRepository) validateAndStockLock(tx *sql.Tx, items []dto.Che
 	}
 
 	query := fmt.Sprintf(
-		`SELECT id, name, price, stock
-         FROM product
-         WHERE id IN (%s)
-         FOR UPDATE`,
+		`SELECT p.id, p.name, p.sku, p.price, p.cost, p.stock, p.reorder_point, p.reorder_quantity, p.category_id, c.name, c.tax_exempt, p.deleted_at, p.has_variants
+         FROM product p
+         JOIN categories c ON p.category_id = c.id
+         WHERE p.id IN (%s)
+         %s`,
 		strings.Join(placeholders, ","),
+		t.dialect.ForUpdate("p"),
 	)
 
 	rows, err := tx.Query(query, productIDs...)
@@ -118,46 +342,54 @@ func (t *transactionRepository) validateAndStockLock(tx *sql.Tx, items []dto.Che
 	defer rows.Close()
 
 	// ✅ GUNAKAN MAP UNTUK LOOKUP AMAN (tanpa asumsi urutan)
-	productMap := make(map[uuid.UUID]struct {
-		Name  string
-		Price int
-		Stock int
-	})
+	productMap := make(map[uuid.UUID]checkoutProduct)
 
 	for rows.Next() {
-		var id uuid.UUID
-		var name string
-		var price, stock int
-		if err := rows.Scan(&id, &name, &price, &stock); err != nil {
+		var prod checkoutProduct
+		if err := rows.Scan(
+			&prod.ID,
+			&prod.Name,
+			&prod.SKU,
+			&prod.Price,
+			&prod.Cost,
+			&prod.Stock,
+			&prod.ReorderPoint,
+			&prod.ReorderQuantity,
+			&prod.CategoryID,
+			&prod.CategoryName,
+			&prod.TaxExempt,
+			&prod.DeletedAt,
+			&prod.HasVariants,
+		); err != nil {
 			return nil, fmt.Errorf("scan product failed: %w", err)
 		}
-		productMap[id] = struct {
-			Name  string
-			Price int
-			Stock int
-		}{name, price, stock}
+		productMap[prod.ID] = prod
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate product failed: %w", err)
 	}
 
-	results := make([]model.Product, len(items))
+	results := make([]checkoutProduct, len(items))
 	for i, item := range items {
 		prod, exists := productMap[item.ProductID]
 		if !exists {
-			return nil, fmt.Errorf("product %s not found", item.ProductID)
+			return nil, fmt.Errorf("%w: %s", utils.ErrProductNotFound, item.ProductID)
 		}
-		if prod.Stock < item.Quantity {
-			return nil, fmt.Errorf("insufficient stock for %s: have %d, need %d",
-				item.ProductID, prod.Stock, item.Quantity)
+		if prod.DeletedAt != nil {
+			return nil, fmt.Errorf("%w: %s", utils.ErrProductArchived, item.ProductID)
 		}
-		results[i] = model.Product{
-			ID:    item.ProductID,
-			Name:  prod.Name,
-			Price: prod.Price,
-			Stock: prod.Stock,
+		if prod.HasVariants {
+			return nil, fmt.Errorf("%w: %s", utils.ErrProductHasVariants, item.ProductID)
 		}
+		if prod.Stock < item.Quantity {
+			return nil, &apperror.InsufficientStockError{
+				ProductID: item.ProductID,
+				Available: prod.Stock,
+				Requested: item.Quantity,
+			}
+		}
+		results[i] = prod
 	}
 
 	return results, nil
@@ -168,15 +400,23 @@ func (t *transactionRepository) bulkInsertDetails(tx *sql.Tx, details []model.Tr
 		return nil
 	}
 
+	const columns = 14
 	valueStrings := make([]string, len(details))
-	args := make([]any, 0, len(details)*6)
+	args := make([]any, 0, len(details)*columns)
 	for i, d := range details {
-		args = append(args, d.ID, d.TransactionID, d.ProductID, d.Quantity, d.Subtotal)
-		valueStrings[i] = fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,NOW())", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5)
+		args = append(args,
+			d.ID, d.TransactionID, d.ProductID, d.ProductName, d.SKU, d.UnitPrice, d.CategoryID, d.CategoryName,
+			d.Quantity, d.UnitCost, d.Discount, d.PromotionID, d.Subtotal, d.CreatedAt,
+		)
+		placeholders := make([]string, columns)
+		for j := range placeholders {
+			placeholders[j] = fmt.Sprintf("$%d", i*columns+j+1)
+		}
+		valueStrings[i] = fmt.Sprintf("(%s)", strings.Join(placeholders, ","))
 	}
 
 	query := fmt.Sprintf(
-		"INSERT INTO transaction_details (id,transaction_id,product_id,quantity,subtotal,created_at) VALUES %s",
+		"INSERT INTO transaction_details (id,transaction_id,product_id,product_name,sku,unit_price,category_id,category_name,quantity,unit_cost,discount,promotion_id,subtotal,created_at) VALUES %s",
 		strings.Join(valueStrings, ","),
 	)
 
@@ -186,3 +426,350 @@ func (t *transactionRepository) bulkInsertDetails(tx *sql.Tx, details []model.Tr
 	}
 	return nil
 }
+
+func (t *transactionRepository) GetTransactions(query *dto.TransactionQuery) ([]*model.Transaction, int, error) {
+	var whereClause strings.Builder
+	var args []any
+	argsIdx := 1
+
+	whereClause.WriteString("WHERE 1=1 ")
+
+	if query.StartDate != "" {
+		start, err := startOfDay(query.StartDate)
+		if err != nil {
+			return nil, 0, err
+		}
+		fmt.Fprintf(&whereClause, " AND t.created_at >= $%d", argsIdx)
+		args = append(args, start)
+		argsIdx++
+	}
+
+	if query.EndDate != "" {
+		end, err := startOfDay(query.EndDate)
+		if err != nil {
+			return nil, 0, err
+		}
+		fmt.Fprintf(&whereClause, " AND t.created_at < $%d", argsIdx)
+		args = append(args, end.AddDate(0, 0, 1))
+		argsIdx++
+	}
+
+	if query.MinAmount != nil {
+		fmt.Fprintf(&whereClause, " AND t.total_amount >= $%d", argsIdx)
+		args = append(args, *query.MinAmount)
+		argsIdx++
+	}
+
+	if query.MaxAmount != nil {
+		fmt.Fprintf(&whereClause, " AND t.total_amount <= $%d", argsIdx)
+		args = append(args, *query.MaxAmount)
+		argsIdx++
+	}
+
+	if query.ProductID != "" {
+		fmt.Fprintf(&whereClause, " AND EXISTS (SELECT 1 FROM transaction_details td WHERE td.transaction_id = t.id AND td.product_id = $%d)", argsIdx)
+		args = append(args, query.ProductID)
+		argsIdx++
+	}
+
+	if query.CashierID != "" {
+		fmt.Fprintf(&whereClause, " AND t.cashier_id = $%d", argsIdx)
+		args = append(args, query.CashierID)
+		argsIdx++
+	}
+
+	if query.TerminalID != "" {
+		fmt.Fprintf(&whereClause, " AND t.terminal_id = $%d", argsIdx)
+		args = append(args, query.TerminalID)
+		argsIdx++
+	}
+
+	if query.ShiftID != "" {
+		fmt.Fprintf(&whereClause, " AND t.shift_id = $%d", argsIdx)
+		args = append(args, query.ShiftID)
+		argsIdx++
+	}
+
+	rows, err := t.db.Query(fmt.Sprintf(`
+		SELECT %s
+		FROM transactions t
+		LEFT JOIN users u ON u.id = t.cashier_id
+		%s
+		ORDER BY t.created_at DESC
+		LIMIT $%d OFFSET $%d`, transactionColumns, whereClause.String(), argsIdx, argsIdx+1),
+		append(args, query.Limit, query.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	transactions := make([]*model.Transaction, 0)
+	transactionMap := make(map[uuid.UUID]*model.Transaction)
+
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		transactions = append(transactions, transaction)
+		transactionMap[transaction.ID] = transaction
+	}
+
+	if rows.Err() != nil {
+		return nil, 0, rows.Err()
+	}
+
+	if err := t.attachDetails(transactionMap); err != nil {
+		return nil, 0, err
+	}
+
+	if err := t.attachPayments(transactionMap); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	err = t.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM transactions t %s`, whereClause.String()),
+		args...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, total, nil
+}
+
+func (t *transactionRepository) GetTransactionByID(id string) (*model.Transaction, error) {
+	transaction, err := scanTransaction(t.db.QueryRow(
+		fmt.Sprintf(`SELECT %s
+		FROM transactions t
+		LEFT JOIN users u ON u.id = t.cashier_id
+		WHERE t.id = $1`, transactionColumns),
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+
+	transactionMap := map[uuid.UUID]*model.Transaction{transaction.ID: transaction}
+	if err := t.attachDetails(transactionMap); err != nil {
+		return nil, err
+	}
+
+	if err := t.attachPayments(transactionMap); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// transactionColumns dipakai bersama LEFT JOIN users u untuk nama kasir
+const transactionColumns = `
+	t.id,
+	t.subtotal,
+	t.service_charge,
+	t.tax_base,
+	t.tax_amount,
+	t.tax_inclusive,
+	t.total_amount,
+	t.discount_amount,
+	t.promotion_id,
+	COALESCE(t.promo_code, ''),
+	t.paid_amount,
+	t.change_amount,
+	t.status,
+	t.cashier_id,
+	COALESCE(u.name, ''),
+	COALESCE(t.terminal_id, ''),
+	t.shift_id,
+	t.created_at`
+
+func scanTransaction(row rowScanner) (*model.Transaction, error) {
+	var transaction model.Transaction
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.Subtotal,
+		&transaction.ServiceCharge,
+		&transaction.TaxBase,
+		&transaction.TaxAmount,
+		&transaction.TaxInclusive,
+		&transaction.TotalAmount,
+		&transaction.DiscountAmount,
+		&transaction.PromotionID,
+		&transaction.PromoCode,
+		&transaction.PaidAmount,
+		&transaction.ChangeAmount,
+		&transaction.Status,
+		&transaction.CashierID,
+		&transaction.CashierName,
+		&transaction.TerminalID,
+		&transaction.ShiftID,
+		&transaction.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.Details = make([]model.TransactionDetail, 0)
+	transaction.Payments = make([]model.TransactionPayment, 0)
+	return &transaction, nil
+}
+
+// attachDetails mengambil detail untuk banyak transaksi sekaligus (hindari N+1 query)
+func (t *transactionRepository) attachDetails(transactions map[uuid.UUID]*model.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	ids := make([]any, 0, len(transactions))
+	placeholders := make([]string, 0, len(transactions))
+	for id := range transactions {
+		ids = append(ids, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(ids)))
+	}
+
+	rows, err := t.db.Query(fmt.Sprintf(`
+		SELECT
+			td.id, td.transaction_id, td.product_id, td.product_name, td.sku, td.unit_price, td.category_id, td.category_name,
+			td.quantity, td.unit_cost, td.discount, td.promotion_id, td.subtotal, td.created_at
+		FROM transaction_details td
+		WHERE td.transaction_id IN (%s)
+		ORDER BY td.created_at, td.id`, strings.Join(placeholders, ",")),
+		ids...,
+	)
+	if err != nil {
+		return fmt.Errorf("query transaction details failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var detail model.TransactionDetail
+		if err := rows.Scan(
+			&detail.ID,
+			&detail.TransactionID,
+			&detail.ProductID,
+			&detail.ProductName,
+			&detail.SKU,
+			&detail.UnitPrice,
+			&detail.CategoryID,
+			&detail.CategoryName,
+			&detail.Quantity,
+			&detail.UnitCost,
+			&detail.Discount,
+			&detail.PromotionID,
+			&detail.Subtotal,
+			&detail.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("scan transaction detail failed: %w", err)
+		}
+
+		transaction := transactions[detail.TransactionID]
+		transaction.Details = append(transaction.Details, detail)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return t.attachDetailModifiers(transactions)
+}
+
+// attachDetailModifiers mengisi snapshot modifier untuk detail yang sudah dimuat attachDetails
+func (t *transactionRepository) attachDetailModifiers(transactions map[uuid.UUID]*model.Transaction) error {
+	details := make(map[uuid.UUID]*model.TransactionDetail)
+	ids := make([]any, 0, len(transactions))
+	placeholders := make([]string, 0, len(transactions))
+	for id, transaction := range transactions {
+		for i := range transaction.Details {
+			details[transaction.Details[i].ID] = &transaction.Details[i]
+		}
+		ids = append(ids, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(ids)))
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+
+	rows, err := t.db.Query(fmt.Sprintf(`
+		SELECT m.transaction_detail_id, m.modifier_id, m.group_name, m.name, m.price_delta
+		FROM transaction_detail_modifiers m
+		JOIN transaction_details td ON td.id = m.transaction_detail_id
+		WHERE td.transaction_id IN (%s)
+		ORDER BY m.position`, strings.Join(placeholders, ",")),
+		ids...,
+	)
+	if err != nil {
+		return fmt.Errorf("query transaction detail modifiers failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var detailID uuid.UUID
+		var modifier model.TransactionDetailModifier
+		if err := rows.Scan(
+			&detailID,
+			&modifier.ModifierID,
+			&modifier.GroupName,
+			&modifier.Name,
+			&modifier.PriceDelta,
+		); err != nil {
+			return fmt.Errorf("scan transaction detail modifier failed: %w", err)
+		}
+
+		detail := details[detailID]
+		detail.Modifiers = append(detail.Modifiers, modifier)
+	}
+
+	return rows.Err()
+}
+
+func (t *transactionRepository) attachPayments(transactions map[uuid.UUID]*model.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	ids := make([]any, 0, len(transactions))
+	placeholders := make([]string, 0, len(transactions))
+	for id := range transactions {
+		ids = append(ids, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(ids)))
+	}
+
+	rows, err := t.db.Query(fmt.Sprintf(`
+		SELECT id, transaction_id, method, amount, change_amount, reference, created_at
+		FROM transaction_payments
+		WHERE transaction_id IN (%s)
+		ORDER BY created_at, id`, strings.Join(placeholders, ",")),
+		ids...,
+	)
+	if err != nil {
+		return fmt.Errorf("query transaction payments failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var payment model.TransactionPayment
+		if err := rows.Scan(
+			&payment.ID,
+			&payment.TransactionID,
+			&payment.Method,
+			&payment.Amount,
+			&payment.ChangeAmount,
+			&payment.Reference,
+			&payment.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("scan transaction payment failed: %w", err)
+		}
+
+		transaction := transactions[payment.TransactionID]
+		transaction.Payments = append(transaction.Payments, payment)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/alert"
+)
+
+func seedPromotion(t *testing.T, db *sql.DB, promotion model.Promotion) {
+	t.Helper()
+
+	mustExec(t, db, `INSERT INTO promotions (id, name, type, code, product_id, value, buy_quantity, get_quantity, min_purchase, start_at, end_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`,
+		newID(t), promotion.Name, promotion.Type, nullString(promotion.Code), promotion.ProductID, promotion.Value,
+		promotion.BuyQuantity, promotion.GetQuantity, promotion.MinPurchase,
+		time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+}
+
+func TestCreateTransactionTotals(t *testing.T) {
+	db := newTestDB(t)
+	cashierID := seedCashier(t, db, "kasir")
+	kopi := seedProduct(t, db, "Kopi", 10000, 10, false)
+	beras := seedProduct(t, db, "Beras", 50000, 10, true)
+
+	seedPromotion(t, db, model.Promotion{Name: "Beli 2 gratis 1", Type: model.PromotionTypeBuyXGetY, ProductID: &kopi, BuyQuantity: 2, GetQuantity: 1})
+	seedPromotion(t, db, model.Promotion{Name: "Hemat", Type: model.PromotionTypeCartNominal, Code: "HEMAT", Value: 7000})
+
+	repo := NewTransactionRepository(db, database.SQLite, model.TaxConfig{Rate: 11}, alert.NewNotifier(""))
+	transaction, err := repo.CreateTransaction(&dto.CheckoutRequest{
+		// kopi dipecah dua baris, buy 2 get 1 tetap berlaku dari total qty 3
+		Items: []dto.CheckoutItem{
+			{ProductID: kopi, Quantity: 2},
+			{ProductID: beras, Quantity: 1},
+			{ProductID: kopi, Quantity: 1},
+		},
+		Payments:  []dto.CheckoutPayment{{Method: model.PaymentMethodCash, Amount: 100000}},
+		PromoCode: "HEMAT",
+		CashierID: &cashierID,
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateTransaction() error = %v", err)
+	}
+
+	// kopi 30.000 - gratis 10.000 dibagi 2:1 ke barisnya, lalu potongan keranjang 7.000
+	// dibagi sebanding subtotal, sisa pembulatan ke baris terakhir
+	wantDetails := []struct {
+		discount int64
+		subtotal int64
+	}{
+		{discount: 6666 + 1333, subtotal: 12001},
+		{discount: 5000, subtotal: 45000},
+		{discount: 3334 + 667, subtotal: 5999},
+	}
+	if len(transaction.Details) != len(wantDetails) {
+		t.Fatalf("len(Details) = %d, want %d", len(transaction.Details), len(wantDetails))
+	}
+	for i, want := range wantDetails {
+		d := transaction.Details[i]
+		if d.Discount != want.discount || d.Subtotal != want.subtotal {
+			t.Errorf("Details[%d] discount/subtotal = %d/%d, want %d/%d", i, d.Discount, d.Subtotal, want.discount, want.subtotal)
+		}
+	}
+
+	if got, want := transaction.DiscountAmount, int64(17000); got != want {
+		t.Errorf("DiscountAmount = %d, want %d", got, want)
+	}
+	if got, want := transaction.Subtotal, int64(63000); got != want {
+		t.Errorf("Subtotal = %d, want %d", got, want)
+	}
+	// PPN hanya dari kopi (beras bebas pajak)
+	if got, want := transaction.TaxBase, int64(18000); got != want {
+		t.Errorf("TaxBase = %d, want %d", got, want)
+	}
+	if got, want := transaction.TaxAmount, int64(1980); got != want {
+		t.Errorf("TaxAmount = %d, want %d", got, want)
+	}
+	if got, want := transaction.TotalAmount, int64(64980); got != want {
+		t.Errorf("TotalAmount = %d, want %d", got, want)
+	}
+	if got, want := transaction.ChangeAmount, int64(35020); got != want {
+		t.Errorf("ChangeAmount = %d, want %d", got, want)
+	}
+
+	if got, want := productStock(t, db, kopi), 7; got != want {
+		t.Errorf("stock kopi = %d, want %d", got, want)
+	}
+
+	var stored int64
+	var createdAt time.Time
+	if err := db.QueryRow("SELECT total_amount, created_at FROM transactions WHERE id = $1", transaction.ID).Scan(&stored, &createdAt); err != nil {
+		t.Fatalf("read transaction: %v", err)
+	}
+	if stored != transaction.TotalAmount {
+		t.Errorf("stored total_amount = %d, want %d", stored, transaction.TotalAmount)
+	}
+	if !transaction.CreatedAt.Equal(createdAt) {
+		t.Errorf("CreatedAt = %v, want %v", transaction.CreatedAt, createdAt)
+	}
+	if !transaction.Details[0].CreatedAt.Equal(createdAt) || !transaction.Payments[0].CreatedAt.Equal(createdAt) {
+		t.Errorf("detail/payment CreatedAt = %v/%v, want %v", transaction.Details[0].CreatedAt, transaction.Payments[0].CreatedAt, createdAt)
+	}
+}
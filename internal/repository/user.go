@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+)
+
+type UserRepository interface {
+	GetUsers(query *dto.UserQuery) ([]*model.User, int, error)
+	GetUserByID(id string) (*model.User, error)
+	GetUserByUsername(username string) (*model.User, error)
+	CountUsers() (int, error)
+	CreateUser(body *model.User) (*model.User, error)
+	UpdateUserByID(id string, body *model.User) (*model.User, error)
+	CreateRefreshToken(token *model.RefreshToken) error
+	RotateRefreshToken(tokenHash string, next *model.RefreshToken) (*model.User, error)
+	RevokeRefreshToken(tokenHash string) error
+}
+
+type userRepo struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewUserRepository(db *sql.DB, dialect database.Dialect) UserRepository {
+	return &userRepo{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+const userColumns = `id, username, name, password_hash, role, is_active, created_at, updated_at`
+
+func scanUser(row rowScanner) (*model.User, error) {
+	var user model.User
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.Name,
+		&user.PasswordHash,
+		&user.Role,
+		&user.IsActive,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (u *userRepo) GetUsers(query *dto.UserQuery) ([]*model.User, int, error) {
+	var whereClause strings.Builder
+	var args []any
+	argsIdx := 1
+
+	whereClause.WriteString("WHERE 1=1 ")
+
+	if query.Search != "" {
+		fmt.Fprintf(&whereClause, " AND (LOWER(username) LIKE LOWER($%d) OR LOWER(name) LIKE LOWER($%d))", argsIdx, argsIdx)
+		args = append(args, "%"+query.Search+"%")
+		argsIdx++
+	}
+
+	if query.Role != "" {
+		fmt.Fprintf(&whereClause, " AND role = $%d", argsIdx)
+		args = append(args, query.Role)
+		argsIdx++
+	}
+
+	rows, err := u.db.Query(fmt.Sprintf(`
+		SELECT %s
+		FROM users
+		%s
+		ORDER BY username
+		LIMIT $%d OFFSET $%d`, userColumns, whereClause.String(), argsIdx, argsIdx+1),
+		append(args, query.Limit, query.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := make([]*model.User, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+
+	if rows.Err() != nil {
+		return nil, 0, rows.Err()
+	}
+
+	var total int
+	err = u.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM users %s`, whereClause.String()),
+		args...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (u *userRepo) GetUserByID(id string) (*model.User, error) {
+	return scanUser(u.db.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM users WHERE id = $1`, userColumns),
+		id,
+	))
+}
+
+func (u *userRepo) GetUserByUsername(username string) (*model.User, error) {
+	return scanUser(u.db.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM users WHERE LOWER(username) = LOWER($1)`, userColumns),
+		username,
+	))
+}
+
+func (u *userRepo) CountUsers() (int, error) {
+	var total int
+	err := u.db.QueryRow("SELECT COUNT(*) FROM users").Scan(&total)
+	return total, err
+}
+
+func (u *userRepo) CreateUser(body *model.User) (*model.User, error) {
+	var exists bool
+	err := u.db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(username) = LOWER($1))", body.Username).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, utils.ErrUsernameTaken
+	}
+
+	err = u.db.QueryRow(
+		fmt.Sprintf(`INSERT INTO users(id, username, name, password_hash, role, is_active, created_at, updated_at)
+		VALUES($1,$2,$3,$4,$5,$6,%[1]s,%[1]s)
+		RETURNING created_at, updated_at`, u.dialect.Now()),
+		body.ID,
+		body.Username,
+		body.Name,
+		body.PasswordHash,
+		body.Role,
+		body.IsActive,
+	).Scan(&body.CreatedAt, &body.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// UpdateUserByID tidak mengubah username. Password hanya diganti jika PasswordHash diisi,
+// dan refresh token dicabut jika user dinonaktifkan
+func (u *userRepo) UpdateUserByID(id string, body *model.User) (*model.User, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	user, err := scanUser(tx.QueryRow(
+		fmt.Sprintf(`UPDATE users SET
+			name = $1,
+			password_hash = CASE WHEN $2 = '' THEN password_hash ELSE $2 END,
+			role = $3,
+			is_active = $4,
+			updated_at = %s
+		WHERE id = $5
+		RETURNING %s`, u.dialect.Now(), userColumns),
+		body.Name,
+		body.PasswordHash,
+		body.Role,
+		body.IsActive,
+		id,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive || body.PasswordHash != "" {
+		_, err = tx.Exec(
+			fmt.Sprintf(`UPDATE refresh_tokens SET revoked_at = %s WHERE user_id = $1 AND revoked_at IS NULL`, u.dialect.Now()),
+			user.ID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("revoke refresh tokens failed: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *userRepo) CreateRefreshToken(token *model.RefreshToken) error {
+	_, err := u.db.Exec(
+		fmt.Sprintf(`INSERT INTO refresh_tokens(id, user_id, token_hash, expires_at, created_at)
+		VALUES($1,$2,$3,$4,%s)`, u.dialect.Now()),
+		token.ID,
+		token.UserID,
+		token.TokenHash,
+		token.ExpiresAt.UTC(),
+	)
+	return err
+}
+
+// RotateRefreshToken mencabut refresh token lama dan menyimpan penggantinya dalam satu transaksi.
+// Token yang sudah dicabut tapi dipakai lagi dianggap bocor, semua sesi user tersebut ikut dicabut.
+func (u *userRepo) RotateRefreshToken(tokenHash string, next *model.RefreshToken) (*model.User, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var current model.RefreshToken
+	err = tx.QueryRow(
+		fmt.Sprintf(`SELECT id, user_id, expires_at, revoked_at FROM refresh_tokens WHERE token_hash = $1 %s`, u.dialect.ForUpdate()),
+		tokenHash,
+	).Scan(&current.ID, &current.UserID, &current.ExpiresAt, &current.RevokedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if current.RevokedAt != nil {
+		_, err = tx.Exec(
+			fmt.Sprintf(`UPDATE refresh_tokens SET revoked_at = %s WHERE user_id = $1 AND revoked_at IS NULL`, u.dialect.Now()),
+			current.UserID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("revoke refresh tokens failed: %w", err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return nil, utils.ErrInvalidRefreshToken
+	}
+
+	if !current.ExpiresAt.After(time.Now()) {
+		return nil, utils.ErrInvalidRefreshToken
+	}
+
+	user, err := scanUser(tx.QueryRow(
+		fmt.Sprintf(`SELECT %s FROM users WHERE id = $1`, userColumns),
+		current.UserID,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, utils.ErrInvalidRefreshToken
+	}
+
+	_, err = tx.Exec(
+		fmt.Sprintf(`UPDATE refresh_tokens SET revoked_at = %s WHERE id = $1`, u.dialect.Now()),
+		current.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	next.UserID = user.ID
+	_, err = tx.Exec(
+		fmt.Sprintf(`INSERT INTO refresh_tokens(id, user_id, token_hash, expires_at, created_at)
+		VALUES($1,$2,$3,$4,%s)`, u.dialect.Now()),
+		next.ID,
+		next.UserID,
+		next.TokenHash,
+		next.ExpiresAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *userRepo) RevokeRefreshToken(tokenHash string) error {
+	_, err := u.db.Exec(
+		fmt.Sprintf(`UPDATE refresh_tokens SET revoked_at = %s WHERE token_hash = $1 AND revoked_at IS NULL`, u.dialect.Now()),
+		tokenHash,
+	)
+	return err
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+)
+
+type validationLookup struct {
+	db *sql.DB
+}
+
+// NewValidationLookup menyediakan data database untuk custom rule di package validator
+func NewValidationLookup(db *sql.DB) validator.Lookup {
+	return &validationLookup{
+		db: db,
+	}
+}
+
+func (v *validationLookup) CategoryExists(id string) (bool, error) {
+	var exists bool
+	err := v.db.QueryRow("SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exists)
+	return exists, err
+}
+
+func (v *validationLookup) ProductNameExists(name, categoryID, excludeID string) (bool, error) {
+	var exists bool
+	err := v.db.QueryRow(
+		`SELECT EXISTS(
+			SELECT 1 FROM product
+			WHERE LOWER(name) = LOWER($1) AND category_id = $2 AND ($3 = '' OR CAST(id AS TEXT) <> $3)
+		)`,
+		name,
+		categoryID,
+		excludeID,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (v *validationLookup) SKUExists(sku, excludeID string) (bool, error) {
+	var exists bool
+	err := v.db.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM product WHERE sku = $1 AND ($2 = '' OR CAST(id AS TEXT) <> $2))`,
+		sku,
+		excludeID,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (v *validationLookup) BarcodeExists(code, excludeID string) (bool, error) {
+	var exists bool
+	err := v.db.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM product_barcodes WHERE code = $1 AND ($2 = '' OR CAST(product_id AS TEXT) <> $2))`,
+		code,
+		excludeID,
+	).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,52 @@
+package route
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/auth"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+func AuthRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
+	userRepo := repository.NewUserRepository(db, database.DialectOf(e.DB_URL))
+	userService := service.NewUserService(userRepo)
+
+	// buat admin pertama dari ADMIN_USERNAME/ADMIN_PASSWORD saat tabel users masih kosong
+	created, err := userService.EnsureAdmin(e.ADMIN_USERNAME, e.ADMIN_PASSWORD)
+	if err != nil {
+		log.Fatalf("error create admin: %v", err)
+	}
+	if created {
+		fmt.Printf("Admin user %q created\n", e.ADMIN_USERNAME)
+	}
+
+	handler := handler.NewAuthHandler(
+		service.NewAuthService(
+			userRepo,
+			auth.NewJWT(e.JWT_SECRET, e.JWT_ACCESS_TTL),
+			e.JWT_REFRESH_TTL,
+		),
+		userService,
+		validator.NewValidation(),
+	)
+	guard := middleware.NewAuth(e)
+
+	// POST http://localhost:8000/api/auth/login
+	mux.HandleFunc("POST /api/auth/login", handler.Login)
+	// POST http://localhost:8000/api/auth/refresh
+	mux.HandleFunc("POST /api/auth/refresh", handler.Refresh)
+	// POST http://localhost:8000/api/auth/logout
+	mux.HandleFunc("POST /api/auth/logout", handler.Logout)
+	// GET http://localhost:8000/api/auth/me
+	mux.HandleFunc("GET /api/auth/me", guard.Require(model.RoleCashier, handler.Me))
+}
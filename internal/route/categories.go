@@ -5,7 +5,11 @@ import (
 	"net/http"
 
 	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
 	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
 	"github.com/Muh-Sidik/kasir-api/internal/repository"
 	"github.com/Muh-Sidik/kasir-api/internal/service"
 )
@@ -13,19 +17,24 @@ import (
 func CategoryRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
 	handler := handler.NewCategoryHandler(
 		service.NewCategoryService(
-			repository.NewCategoryRepository(db),
+			repository.NewCategoryRepository(db, database.DialectOf(e.DB_URL)),
 		),
+		validator.NewValidation(),
 	)
+	guard := middleware.NewAuth(e)
+
+	// POST http://localhost:8000/api/categories/{id}/restore
+	mux.HandleFunc("POST /api/categories/{id}/restore", guard.Require(model.RoleManager, handler.RestoreCategoryByID))
 
 	// DELETE http://localhost:8000/api/categories/{id}
-	mux.HandleFunc("DELETE /api/categories/{id}", handler.DeleteCategoryByID)
+	mux.HandleFunc("DELETE /api/categories/{id}", guard.Require(model.RoleManager, handler.DeleteCategoryByID))
 	// PUT http://localhost:8000/api/categories/{id}
-	mux.HandleFunc("PUT /api/categories/{id}", handler.UpdateCategoryByID)
+	mux.HandleFunc("PUT /api/categories/{id}", guard.Require(model.RoleManager, handler.UpdateCategoryByID))
 	// GET http://localhost:8000/api/categories/{id}
-	mux.HandleFunc("GET /api/categories/{id}", handler.GetCategoryByID)
+	mux.HandleFunc("GET /api/categories/{id}", guard.Require(model.RoleCashier, handler.GetCategoryByID))
 
 	// POST http://localhost:8000/api/categories
-	mux.HandleFunc("POST /api/categories", handler.CreateCategory)
+	mux.HandleFunc("POST /api/categories", guard.Require(model.RoleManager, handler.CreateCategory))
 	// GET http://localhost:8000/api/categories
-	mux.HandleFunc("GET /api/categories", handler.Categories)
+	mux.HandleFunc("GET /api/categories", guard.Require(model.RoleCashier, handler.Categories))
 }
@@ -0,0 +1,37 @@
+package route
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+func ModifierRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
+	handler := handler.NewModifierHandler(
+		service.NewModifierService(
+			repository.NewModifierRepository(db, database.DialectOf(e.DB_URL)),
+		),
+		validator.NewValidation(),
+	)
+	guard := middleware.NewAuth(e)
+
+	// DELETE http://localhost:8000/api/modifier-groups/{id}
+	mux.HandleFunc("DELETE /api/modifier-groups/{id}", guard.Require(model.RoleManager, handler.DeleteModifierGroupByID))
+	// PUT http://localhost:8000/api/modifier-groups/{id}
+	mux.HandleFunc("PUT /api/modifier-groups/{id}", guard.Require(model.RoleManager, handler.UpdateModifierGroupByID))
+	// GET http://localhost:8000/api/modifier-groups/{id}
+	mux.HandleFunc("GET /api/modifier-groups/{id}", guard.Require(model.RoleCashier, handler.GetModifierGroupByID))
+
+	// POST http://localhost:8000/api/modifier-groups
+	mux.HandleFunc("POST /api/modifier-groups", guard.Require(model.RoleManager, handler.CreateModifierGroup))
+	// GET http://localhost:8000/api/modifier-groups
+	mux.HandleFunc("GET /api/modifier-groups", guard.Require(model.RoleCashier, handler.ModifierGroups))
+}
@@ -5,7 +5,11 @@ import (
 	"net/http"
 
 	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
 	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
 	"github.com/Muh-Sidik/kasir-api/internal/repository"
 	"github.com/Muh-Sidik/kasir-api/internal/service"
 )
@@ -13,19 +17,59 @@ import (
 func ProductRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
 	handler := handler.NewProductHandler(
 		service.NewProductService(
-			repository.NewProductRepository(db),
+			repository.NewProductRepository(db, database.DialectOf(e.DB_URL)),
 		),
+		validator.NewValidation(),
 	)
+	guard := middleware.NewAuth(e)
+
+	// GET http://localhost:8000/api/product/low-stock
+	mux.HandleFunc("GET /api/product/low-stock", guard.Require(model.RoleManager, handler.LowStockProducts))
+
+	// POST http://localhost:8000/api/product/labels
+	mux.HandleFunc("POST /api/product/labels", guard.Require(model.RoleManager, handler.PrintLabels))
+
+	// GET http://localhost:8000/api/product/barcode/{code}
+	// GET http://localhost:8000/api/product/{id}/movements
+	// GET http://localhost:8000/api/product/{id}/barcode
+	// pola di atas bentrok di ServeMux (contoh /api/product/barcode/movements),
+	// jadi didaftarkan sebagai satu pola lalu dipilih di sini
+	barcodeLookup := guard.Require(model.RoleCashier, handler.GetProductByBarcode)
+	stockMovements := guard.Require(model.RoleManager, handler.GetStockMovements)
+	barcodeImage := guard.Require(model.RoleCashier, handler.GetBarcodeImage)
+	mux.HandleFunc("GET /api/product/{id}/{sub}", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.PathValue("id") == "barcode":
+			r.SetPathValue("code", r.PathValue("sub"))
+			barcodeLookup(w, r)
+		case r.PathValue("sub") == "movements":
+			stockMovements(w, r)
+		case r.PathValue("sub") == "barcode":
+			barcodeImage(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	// POST http://localhost:8000/api/product/{id}/movements
+	mux.HandleFunc("POST /api/product/{id}/movements", guard.Require(model.RoleManager, handler.CreateStockMovement))
+
+	// POST http://localhost:8000/api/product/{id}/variants
+	mux.HandleFunc("POST /api/product/{id}/variants", guard.Require(model.RoleManager, handler.CreateVariant))
+	// PUT http://localhost:8000/api/product/{id}/variants/{variantId}
+	mux.HandleFunc("PUT /api/product/{id}/variants/{variantId}", guard.Require(model.RoleManager, handler.UpdateVariant))
+
+	// POST http://localhost:8000/api/product/{id}/restore
+	mux.HandleFunc("POST /api/product/{id}/restore", guard.Require(model.RoleManager, handler.RestoreProductByID))
 
 	// DELETE http://localhost:8000/api/product/{id}
-	mux.HandleFunc("DELETE /api/product/{id}", handler.DeleteProductByID)
+	mux.HandleFunc("DELETE /api/product/{id}", guard.Require(model.RoleManager, handler.DeleteProductByID))
 	// PUT http://localhost:8000/api/product/{id}
-	mux.HandleFunc("PUT /api/product/{id}", handler.UpdateProductByID)
+	mux.HandleFunc("PUT /api/product/{id}", guard.Require(model.RoleManager, handler.UpdateProductByID))
 	// GET http://localhost:8000/api/product/{id}
-	mux.HandleFunc("GET /api/product/{id}", handler.GetProductByID)
+	mux.HandleFunc("GET /api/product/{id}", guard.Require(model.RoleCashier, handler.GetProductByID))
 
 	// POST http://localhost:8000/api/product
-	mux.HandleFunc("POST /api/product", handler.CreateProduct)
+	mux.HandleFunc("POST /api/product", guard.Require(model.RoleManager, handler.CreateProduct))
 	// GET http://localhost:8000/api/product
-	mux.HandleFunc("GET /api/product", handler.Products)
+	mux.HandleFunc("GET /api/product", guard.Require(model.RoleCashier, handler.Products))
 }
@@ -0,0 +1,37 @@
+package route
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+func PromotionRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
+	handler := handler.NewPromotionHandler(
+		service.NewPromotionService(
+			repository.NewPromotionRepository(db, database.DialectOf(e.DB_URL)),
+		),
+		validator.NewValidation(),
+	)
+	guard := middleware.NewAuth(e)
+
+	// DELETE http://localhost:8000/api/promotions/{id}
+	mux.HandleFunc("DELETE /api/promotions/{id}", guard.Require(model.RoleManager, handler.DeletePromotionByID))
+	// PUT http://localhost:8000/api/promotions/{id}
+	mux.HandleFunc("PUT /api/promotions/{id}", guard.Require(model.RoleManager, handler.UpdatePromotionByID))
+	// GET http://localhost:8000/api/promotions/{id}
+	mux.HandleFunc("GET /api/promotions/{id}", guard.Require(model.RoleCashier, handler.GetPromotionByID))
+
+	// POST http://localhost:8000/api/promotions
+	mux.HandleFunc("POST /api/promotions", guard.Require(model.RoleManager, handler.CreatePromotion))
+	// GET http://localhost:8000/api/promotions
+	mux.HandleFunc("GET /api/promotions", guard.Require(model.RoleCashier, handler.Promotions))
+}
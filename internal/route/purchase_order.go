@@ -0,0 +1,42 @@
+package route
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+func PurchaseOrderRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
+	handler := handler.NewPurchaseOrderHandler(
+		service.NewPurchaseOrderService(
+			repository.NewPurchaseOrderRepository(db, database.DialectOf(e.DB_URL)),
+		),
+		validator.NewValidation(),
+	)
+	guard := middleware.NewAuth(e)
+
+	// POST http://localhost:8000/api/purchase-orders/{id}/send
+	mux.HandleFunc("POST /api/purchase-orders/{id}/send", guard.Require(model.RoleManager, handler.SendPurchaseOrder))
+	// POST http://localhost:8000/api/purchase-orders/{id}/receive
+	mux.HandleFunc("POST /api/purchase-orders/{id}/receive", guard.Require(model.RoleManager, handler.ReceivePurchaseOrder))
+	// POST http://localhost:8000/api/purchase-orders/{id}/close
+	mux.HandleFunc("POST /api/purchase-orders/{id}/close", guard.Require(model.RoleManager, handler.ClosePurchaseOrder))
+
+	// PUT http://localhost:8000/api/purchase-orders/{id}
+	mux.HandleFunc("PUT /api/purchase-orders/{id}", guard.Require(model.RoleManager, handler.UpdatePurchaseOrderByID))
+	// GET http://localhost:8000/api/purchase-orders/{id}
+	mux.HandleFunc("GET /api/purchase-orders/{id}", guard.Require(model.RoleManager, handler.GetPurchaseOrderByID))
+
+	// POST http://localhost:8000/api/purchase-orders
+	mux.HandleFunc("POST /api/purchase-orders", guard.Require(model.RoleManager, handler.CreatePurchaseOrder))
+	// GET http://localhost:8000/api/purchase-orders
+	mux.HandleFunc("GET /api/purchase-orders", guard.Require(model.RoleManager, handler.PurchaseOrders))
+}
@@ -0,0 +1,32 @@
+package route
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+func RefundRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
+	handler := handler.NewRefundHandler(
+		service.NewRefundService(
+			repository.NewRefundRepository(db, database.DialectOf(e.DB_URL)),
+		),
+		validator.NewValidation(),
+	)
+	guard := middleware.NewAuth(e)
+
+	// POST http://localhost:8000/api/transactions/{id}/void
+	mux.HandleFunc("POST /api/transactions/{id}/void", guard.Require(model.RoleManager, handler.VoidTransaction))
+	// POST http://localhost:8000/api/transactions/{id}/refund
+	mux.HandleFunc("POST /api/transactions/{id}/refund", guard.Require(model.RoleManager, handler.RefundTransaction))
+	// GET http://localhost:8000/api/transactions/{id}/refunds
+	mux.HandleFunc("GET /api/transactions/{id}/refunds", guard.Require(model.RoleCashier, handler.GetRefundsByTransactionID))
+}
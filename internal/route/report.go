@@ -6,6 +6,8 @@ import (
 
 	"github.com/Muh-Sidik/kasir-api/config"
 	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
 	"github.com/Muh-Sidik/kasir-api/internal/repository"
 	"github.com/Muh-Sidik/kasir-api/internal/service"
 )
@@ -16,7 +18,14 @@ func ReportRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
 			repository.NewReportRepository(db),
 		),
 	)
+	guard := middleware.NewAuth(e)
 
 	// GET http://localhost:8000/api/report
-	mux.HandleFunc("GET /api/report", handler.Report)
+	mux.HandleFunc("GET /api/report", guard.Require(model.RoleManager, handler.Report))
+	// GET http://localhost:8000/api/report/margin
+	mux.HandleFunc("GET /api/report/margin", guard.Require(model.RoleManager, handler.MarginReport))
+	// GET http://localhost:8000/api/report/modifiers
+	mux.HandleFunc("GET /api/report/modifiers", guard.Require(model.RoleManager, handler.ModifierReport))
+	// GET http://localhost:8000/api/report/shift/{id}
+	mux.HandleFunc("GET /api/report/shift/{id}", guard.Require(model.RoleCashier, handler.ShiftReport))
 }
@@ -6,6 +6,8 @@ import (
 
 	"github.com/Muh-Sidik/kasir-api/config"
 	"github.com/Muh-Sidik/kasir-api/internal/pkg/response"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
 
@@ -34,9 +36,20 @@ func Setup(mux *http.ServeMux, e *config.Env, db *sql.DB) {
 		).JSON(w, http.StatusOK)
 	})
 
+	validator.NewValidation().UseLookup(repository.NewValidationLookup(db))
+
+	AuthRoute(mux, e, db)
+	UserRoute(mux, e, db)
 	CategoryRoute(mux, e, db)
 	ProductRoute(mux, e, db)
+	ModifierRoute(mux, e, db)
+	PromotionRoute(mux, e, db)
 	TransactionRoute(mux, e, db)
+	RefundRoute(mux, e, db)
+	ShiftRoute(mux, e, db)
+	StockOpnameRoute(mux, e, db)
+	SupplierRoute(mux, e, db)
+	PurchaseOrderRoute(mux, e, db)
 	ReportRoute(mux, e, db)
 	// add other route...
 }
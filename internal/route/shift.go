@@ -0,0 +1,39 @@
+package route
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+func ShiftRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
+	handler := handler.NewShiftHandler(
+		service.NewShiftService(
+			repository.NewShiftRepository(db, database.DialectOf(e.DB_URL)),
+		),
+		validator.NewValidation(),
+	)
+	guard := middleware.NewAuth(e)
+
+	// POST http://localhost:8000/api/shifts/open
+	mux.HandleFunc("POST /api/shifts/open", guard.Require(model.RoleCashier, handler.OpenShift))
+	// GET http://localhost:8000/api/shifts/current
+	mux.HandleFunc("GET /api/shifts/current", guard.Require(model.RoleCashier, handler.CurrentShift))
+	// POST http://localhost:8000/api/shifts/{id}/cash-movements
+	mux.HandleFunc("POST /api/shifts/{id}/cash-movements", guard.Require(model.RoleCashier, handler.AddCashMovement))
+	// POST http://localhost:8000/api/shifts/{id}/close
+	mux.HandleFunc("POST /api/shifts/{id}/close", guard.Require(model.RoleCashier, handler.CloseShift))
+	// GET http://localhost:8000/api/shifts/{id}
+	mux.HandleFunc("GET /api/shifts/{id}", guard.Require(model.RoleCashier, handler.GetShiftByID))
+
+	// GET http://localhost:8000/api/shifts
+	mux.HandleFunc("GET /api/shifts", guard.Require(model.RoleManager, handler.Shifts))
+}
@@ -0,0 +1,39 @@
+package route
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+func StockOpnameRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
+	handler := handler.NewStockOpnameHandler(
+		service.NewStockOpnameService(
+			repository.NewStockOpnameRepository(db, database.DialectOf(e.DB_URL)),
+		),
+		validator.NewValidation(),
+	)
+	guard := middleware.NewAuth(e)
+
+	// POST http://localhost:8000/api/stock-opnames/{id}/counts
+	mux.HandleFunc("POST /api/stock-opnames/{id}/counts", guard.Require(model.RoleCashier, handler.SubmitCounts))
+	// POST http://localhost:8000/api/stock-opnames/{id}/post
+	mux.HandleFunc("POST /api/stock-opnames/{id}/post", guard.Require(model.RoleManager, handler.PostStockOpname))
+	// POST http://localhost:8000/api/stock-opnames/{id}/cancel
+	mux.HandleFunc("POST /api/stock-opnames/{id}/cancel", guard.Require(model.RoleManager, handler.CancelStockOpname))
+	// GET http://localhost:8000/api/stock-opnames/{id}
+	mux.HandleFunc("GET /api/stock-opnames/{id}", guard.Require(model.RoleCashier, handler.GetStockOpnameByID))
+
+	// POST http://localhost:8000/api/stock-opnames
+	mux.HandleFunc("POST /api/stock-opnames", guard.Require(model.RoleManager, handler.CreateStockOpname))
+	// GET http://localhost:8000/api/stock-opnames
+	mux.HandleFunc("GET /api/stock-opnames", guard.Require(model.RoleCashier, handler.StockOpnames))
+}
@@ -0,0 +1,37 @@
+package route
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+func SupplierRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
+	handler := handler.NewSupplierHandler(
+		service.NewSupplierService(
+			repository.NewSupplierRepository(db, database.DialectOf(e.DB_URL)),
+		),
+		validator.NewValidation(),
+	)
+	guard := middleware.NewAuth(e)
+
+	// DELETE http://localhost:8000/api/suppliers/{id}
+	mux.HandleFunc("DELETE /api/suppliers/{id}", guard.Require(model.RoleManager, handler.DeleteSupplierByID))
+	// PUT http://localhost:8000/api/suppliers/{id}
+	mux.HandleFunc("PUT /api/suppliers/{id}", guard.Require(model.RoleManager, handler.UpdateSupplierByID))
+	// GET http://localhost:8000/api/suppliers/{id}
+	mux.HandleFunc("GET /api/suppliers/{id}", guard.Require(model.RoleManager, handler.GetSupplierByID))
+
+	// POST http://localhost:8000/api/suppliers
+	mux.HandleFunc("POST /api/suppliers", guard.Require(model.RoleManager, handler.CreateSupplier))
+	// GET http://localhost:8000/api/suppliers
+	mux.HandleFunc("GET /api/suppliers", guard.Require(model.RoleManager, handler.Suppliers))
+}
@@ -5,7 +5,12 @@ import (
 	"net/http"
 
 	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
 	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/alert"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
 	"github.com/Muh-Sidik/kasir-api/internal/repository"
 	"github.com/Muh-Sidik/kasir-api/internal/service"
 )
@@ -13,10 +18,21 @@ import (
 func TransactionRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
 	handler := handler.NewTransactionHandler(
 		service.NewTransactionService(
-			repository.NewTransactionRepository(db),
+			repository.NewTransactionRepository(db, database.DialectOf(e.DB_URL), model.TaxConfig{
+				Rate:              e.TAX_RATE,
+				Inclusive:         e.TAX_INCLUSIVE,
+				ServiceChargeRate: e.SERVICE_CHARGE_RATE,
+			}, alert.NewNotifier(e.LOW_STOCK_WEBHOOK_URL)),
 		),
+		validator.NewValidation(),
 	)
+	guard := middleware.NewAuth(e)
+
+	// GET http://localhost:8000/api/transactions/{id}
+	mux.HandleFunc("GET /api/transactions/{id}", guard.Require(model.RoleCashier, handler.GetTransactionByID))
+	// GET http://localhost:8000/api/transactions
+	mux.HandleFunc("GET /api/transactions", guard.Require(model.RoleCashier, handler.Transactions))
 
 	// POST http://localhost:8000/api/checkout
-	mux.HandleFunc("POST /api/checkout", handler.HandleCheckout)
+	mux.HandleFunc("POST /api/checkout", guard.Require(model.RoleCashier, handler.HandleCheckout))
 }
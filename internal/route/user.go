@@ -0,0 +1,35 @@
+package route
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Muh-Sidik/kasir-api/config"
+	"github.com/Muh-Sidik/kasir-api/database"
+	"github.com/Muh-Sidik/kasir-api/internal/handler"
+	"github.com/Muh-Sidik/kasir-api/internal/middleware"
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/validator"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/Muh-Sidik/kasir-api/internal/service"
+)
+
+func UserRoute(mux *http.ServeMux, e *config.Env, db *sql.DB) {
+	handler := handler.NewUserHandler(
+		service.NewUserService(
+			repository.NewUserRepository(db, database.DialectOf(e.DB_URL)),
+		),
+		validator.NewValidation(),
+	)
+	guard := middleware.NewAuth(e)
+
+	// PUT http://localhost:8000/api/users/{id}
+	mux.HandleFunc("PUT /api/users/{id}", guard.Require(model.RoleAdmin, handler.UpdateUserByID))
+	// GET http://localhost:8000/api/users/{id}
+	mux.HandleFunc("GET /api/users/{id}", guard.Require(model.RoleAdmin, handler.GetUserByID))
+
+	// POST http://localhost:8000/api/users
+	mux.HandleFunc("POST /api/users", guard.Require(model.RoleAdmin, handler.CreateUser))
+	// GET http://localhost:8000/api/users
+	mux.HandleFunc("GET /api/users", guard.Require(model.RoleAdmin, handler.Users))
+}
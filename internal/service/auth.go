@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/auth"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/gofrs/uuid/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type AuthService interface {
+	Login(body *dto.LoginRequest) (*model.AuthToken, error)
+	Refresh(body *dto.RefreshRequest) (*model.AuthToken, error)
+	Logout(body *dto.RefreshRequest) error
+}
+
+type authService struct {
+	repo       repository.UserRepository
+	jwt        *auth.JWT
+	refreshTTL time.Duration
+}
+
+func NewAuthService(repo repository.UserRepository, jwt *auth.JWT, refreshTTL time.Duration) AuthService {
+	return &authService{
+		repo:       repo,
+		jwt:        jwt,
+		refreshTTL: refreshTTL,
+	}
+}
+
+func (s *authService) Login(body *dto.LoginRequest) (*model.AuthToken, error) {
+	user, err := s.repo.GetUserByUsername(body.Username)
+	if err != nil {
+		if errors.Is(err, utils.ErrUserNotFound) {
+			return nil, utils.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(body.Password)); err != nil {
+		return nil, utils.ErrInvalidCredentials
+	}
+
+	if !user.IsActive {
+		return nil, utils.ErrInvalidCredentials
+	}
+
+	refreshToken, refresh, err := s.newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	refresh.UserID = user.ID
+
+	if err := s.repo.CreateRefreshToken(refresh); err != nil {
+		return nil, err
+	}
+
+	return s.issue(user, refreshToken)
+}
+
+// Refresh memakai rotasi token: refresh token lama langsung dicabut
+func (s *authService) Refresh(body *dto.RefreshRequest) (*model.AuthToken, error) {
+	refreshToken, refresh, err := s.newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.RotateRefreshToken(auth.HashToken(body.RefreshToken), refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issue(user, refreshToken)
+}
+
+func (s *authService) Logout(body *dto.RefreshRequest) error {
+	return s.repo.RevokeRefreshToken(auth.HashToken(body.RefreshToken))
+}
+
+func (s *authService) newRefreshToken() (string, *model.RefreshToken, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", nil, err
+	}
+
+	token, hash, err := auth.NewRefreshToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, &model.RefreshToken{
+		ID:        id,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(s.refreshTTL),
+	}, nil
+}
+
+func (s *authService) issue(user *model.User, refreshToken string) (*model.AuthToken, error) {
+	accessToken, err := s.jwt.Sign(auth.Claims{
+		Subject:  user.ID.String(),
+		Username: user.Username,
+		Role:     user.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AuthToken{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.jwt.TTL().Seconds()),
+		User:         user,
+	}, nil
+}
@@ -9,11 +9,12 @@ import (
 )
 
 type CategoryService interface {
-	GetCategories(paginate *request.PaginateQuery, search string) ([]*model.Categories, int, error)
+	GetCategories(paginate *request.PaginateQuery, search string, includeArchived bool) ([]*model.Categories, int, error)
 	GetCategoryByID(id string) (*model.Categories, error)
 	CreateCategory(category *dto.CategoryRequest) (*model.Categories, error)
 	UpdateCategoryByID(id string, category *dto.CategoryRequest) (*model.Categories, error)
 	DeleteCategoryByID(id string) error
+	RestoreCategoryByID(id string) (*model.Categories, error)
 }
 
 type categoryService struct {
@@ -26,8 +27,8 @@ func NewCategoryService(categoryRepo repository.CategoryRepository) CategoryServ
 	}
 }
 
-func (s *categoryService) GetCategories(paginate *request.PaginateQuery, search string) ([]*model.Categories, int, error) {
-	return s.categoryRepo.GetCategories(paginate, search)
+func (s *categoryService) GetCategories(paginate *request.PaginateQuery, search string, includeArchived bool) ([]*model.Categories, int, error) {
+	return s.categoryRepo.GetCategories(paginate, search, includeArchived)
 }
 
 func (s *categoryService) CreateCategory(req *dto.CategoryRequest) (*model.Categories, error) {
@@ -39,6 +40,7 @@ func (s *categoryService) CreateCategory(req *dto.CategoryRequest) (*model.Categ
 		ID:          id,
 		Name:        req.Name,
 		Description: req.Description,
+		TaxExempt:   req.TaxExempt,
 	})
 }
 
@@ -50,9 +52,14 @@ func (s *categoryService) DeleteCategoryByID(id string) error {
 	return s.categoryRepo.DeleteCategoryByID(id)
 }
 
+func (s *categoryService) RestoreCategoryByID(id string) (*model.Categories, error) {
+	return s.categoryRepo.RestoreCategoryByID(id)
+}
+
 func (s *categoryService) UpdateCategoryByID(id string, req *dto.CategoryRequest) (*model.Categories, error) {
 	return s.categoryRepo.UpdateCategoryByID(id, &model.Categories{
 		Name:        req.Name,
 		Description: req.Description,
+		TaxExempt:   req.TaxExempt,
 	})
 }
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/gofrs/uuid/v5"
+)
+
+type ModifierService interface {
+	GetModifierGroups(query *dto.ModifierGroupQuery) ([]*model.ModifierGroup, int, error)
+	GetModifierGroupByID(id string) (*model.ModifierGroup, error)
+	CreateModifierGroup(body *dto.ModifierGroupRequest) (*model.ModifierGroup, error)
+	UpdateModifierGroupByID(id string, body *dto.ModifierGroupRequest) (*model.ModifierGroup, error)
+	DeleteModifierGroupByID(id string) error
+}
+
+type modifierService struct {
+	repo repository.ModifierRepository
+}
+
+func NewModifierService(repo repository.ModifierRepository) ModifierService {
+	return &modifierService{
+		repo: repo,
+	}
+}
+
+func (s *modifierService) GetModifierGroups(query *dto.ModifierGroupQuery) ([]*model.ModifierGroup, int, error) {
+	return s.repo.GetModifierGroups(query)
+}
+
+func (s *modifierService) GetModifierGroupByID(id string) (*model.ModifierGroup, error) {
+	return s.repo.GetModifierGroupByID(id)
+}
+
+func (s *modifierService) CreateModifierGroup(body *dto.ModifierGroupRequest) (*model.ModifierGroup, error) {
+	group, err := toModifierGroup(body)
+	if err != nil {
+		return nil, err
+	}
+
+	group.ID, err = uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateModifierGroup(group)
+}
+
+func (s *modifierService) UpdateModifierGroupByID(id string, body *dto.ModifierGroupRequest) (*model.ModifierGroup, error) {
+	group, err := toModifierGroup(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateModifierGroupByID(id, group)
+}
+
+func (s *modifierService) DeleteModifierGroupByID(id string) error {
+	return s.repo.DeleteModifierGroupByID(id)
+}
+
+// toModifierGroup memastikan min_select masih bisa dipenuhi oleh jumlah modifier.
+// Modifier tanpa id dibuatkan id baru di repository
+func toModifierGroup(body *dto.ModifierGroupRequest) (*model.ModifierGroup, error) {
+	if body.MinSelect > len(body.Modifiers) {
+		return nil, fmt.Errorf("%w: min_select %d is more than %d modifiers", utils.ErrInvalidModifierGroup, body.MinSelect, len(body.Modifiers))
+	}
+
+	group := &model.ModifierGroup{
+		Name:        body.Name,
+		MinSelect:   body.MinSelect,
+		MaxSelect:   body.MaxSelect,
+		Modifiers:   make([]model.Modifier, 0, len(body.Modifiers)),
+		ProductIDs:  body.ProductIDs,
+		CategoryIDs: body.CategoryIDs,
+	}
+
+	for _, modifier := range body.Modifiers {
+		item := model.Modifier{
+			Name:       modifier.Name,
+			PriceDelta: modifier.PriceDelta,
+		}
+		if modifier.ID != nil {
+			item.ID = *modifier.ID
+		}
+		group.Modifiers = append(group.Modifiers, item)
+	}
+
+	return group, nil
+}
@@ -1,8 +1,15 @@
 package service
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/Muh-Sidik/kasir-api/internal/model"
 	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/barcode"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/label"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
 	"github.com/Muh-Sidik/kasir-api/internal/repository"
 	"github.com/gofrs/uuid/v5"
 )
@@ -10,9 +17,18 @@ import (
 type ProductService interface {
 	GetProduct(dto *dto.ProductQuery) ([]*model.ProductCategory, int, error)
 	GetProductByID(id string) (*model.ProductCategory, error)
+	GetProductByBarcode(code string) (*model.ProductCategory, error)
 	CreateProduct(body *dto.ProductRequest) (*model.Product, error)
 	UpdateProductByID(id string, body *dto.ProductRequest) (*model.Product, error)
+	CreateVariant(parentID string, body *dto.VariantRequest) (*model.Product, error)
+	UpdateVariant(parentID, variantID string, body *dto.VariantRequest) (*model.Product, error)
 	DeleteProductByID(id string) error
+	RestoreProductByID(id string) (*model.ProductCategory, error)
+	GetStockMovements(productID string, query *dto.StockMovementQuery) ([]*model.StockMovement, int, error)
+	CreateStockMovement(productID string, body *dto.StockMovementRequest) (*model.StockMovement, error)
+	GetLowStockProducts(query *dto.LowStockQuery) ([]*model.LowStockProduct, int, error)
+	GetBarcode(id string, query *dto.BarcodeQuery) (*barcode.Barcode, error)
+	GetLabels(body *dto.LabelRequest) ([]label.Label, error)
 }
 
 type productService struct {
@@ -40,23 +56,43 @@ func (s *productService) CreateProduct(body *dto.ProductRequest) (*model.Product
 		return nil, err
 	}
 
-	return s.repo.CreateProduct(&model.Product{
+	product := &model.Product{
 		ID:         id,
 		Name:       body.Name,
-		Stock:      body.Stock,
+		SKU:        optionalString(body.SKU),
+		Barcodes:   body.Barcodes,
 		Price:      body.Price,
 		CategoryID: validCategory,
-	})
+
+		ReorderPoint:    body.ReorderPoint,
+		ReorderQuantity: body.ReorderQuantity,
+	}
+	if body.Stock != nil {
+		product.Stock = *body.Stock
+	}
+	if body.Cost != nil {
+		product.Cost = *body.Cost
+	}
+
+	return s.repo.CreateProduct(product, body.UserID)
 }
 
 func (s *productService) GetProductByID(id string) (*model.ProductCategory, error) {
 	return s.repo.GetProductByID(id)
 }
 
+func (s *productService) GetProductByBarcode(code string) (*model.ProductCategory, error) {
+	return s.repo.GetProductByBarcode(code)
+}
+
 func (s *productService) DeleteProductByID(id string) error {
 	return s.repo.DeleteProductByID(id)
 }
 
+func (s *productService) RestoreProductByID(id string) (*model.ProductCategory, error) {
+	return s.repo.RestoreProductByID(id)
+}
+
 func (s *productService) UpdateProductByID(id string, body *dto.ProductRequest) (*model.Product, error) {
 	validCategory, err := uuid.FromString(body.CategoryID)
 	if err != nil {
@@ -64,8 +100,159 @@ func (s *productService) UpdateProductByID(id string, body *dto.ProductRequest)
 	}
 	return s.repo.UpdateProductByID(id, &model.Product{
 		Name:       body.Name,
-		Stock:      body.Stock,
+		SKU:        optionalString(body.SKU),
+		Barcodes:   body.Barcodes,
 		Price:      body.Price,
 		CategoryID: validCategory,
+
+		ReorderPoint:    body.ReorderPoint,
+		ReorderQuantity: body.ReorderQuantity,
+	}, body.Stock, body.Cost, body.UserID)
+}
+
+func (s *productService) CreateVariant(parentID string, body *dto.VariantRequest) (*model.Product, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	variant := variantFromRequest(body)
+	variant.ID = id
+	if body.Stock != nil {
+		variant.Stock = *body.Stock
+	}
+	if body.Cost != nil {
+		variant.Cost = *body.Cost
+	}
+
+	return s.repo.CreateVariant(parentID, variant, body.UserID)
+}
+
+func (s *productService) UpdateVariant(parentID, variantID string, body *dto.VariantRequest) (*model.Product, error) {
+	return s.repo.UpdateVariant(parentID, variantID, variantFromRequest(body), body.Stock, body.Cost, body.UserID)
+}
+
+func variantFromRequest(body *dto.VariantRequest) *model.Product {
+	options := make([]model.VariantOption, len(body.Options))
+	for i, option := range body.Options {
+		options[i] = model.VariantOption{
+			Name:  strings.TrimSpace(option.Name),
+			Value: strings.TrimSpace(option.Value),
+		}
+	}
+
+	return &model.Product{
+		SKU:      optionalString(body.SKU),
+		Barcodes: body.Barcodes,
+		Price:    body.Price,
+		Options:  options,
+
+		ReorderPoint:    body.ReorderPoint,
+		ReorderQuantity: body.ReorderQuantity,
+	}
+}
+
+func (s *productService) GetStockMovements(productID string, query *dto.StockMovementQuery) ([]*model.StockMovement, int, error) {
+	if _, err := s.repo.GetProductByID(productID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.repo.GetStockMovements(productID, query)
+}
+
+func (s *productService) CreateStockMovement(productID string, body *dto.StockMovementRequest) (*model.StockMovement, error) {
+	parsedProductID, err := uuid.FromString(productID)
+	if err != nil {
+		return nil, utils.ErrProductNotFound
+	}
+
+	return s.repo.CreateStockMovement(&model.StockMovement{
+		ProductID: parsedProductID,
+		Type:      body.Type,
+		Quantity:  body.Quantity,
+		Reason:    body.Reason,
+		CreatedBy: body.UserID,
 	})
 }
+
+func (s *productService) GetLowStockProducts(query *dto.LowStockQuery) ([]*model.LowStockProduct, int, error) {
+	return s.repo.GetLowStockProducts(query)
+}
+
+// GetBarcode meng-encode barcode produk. Kode yang diminta harus salah satu barcode atau SKU
+// produk tersebut supaya endpoint ini tidak dipakai mencetak kode sembarang
+func (s *productService) GetBarcode(id string, query *dto.BarcodeQuery) (*barcode.Barcode, error) {
+	product, err := s.repo.GetProductByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	code := query.Code
+	if code == "" {
+		code = labelCode(product)
+	} else if !slices.Contains(product.Barcodes, code) && (product.SKU == nil || *product.SKU != code) {
+		return nil, fmt.Errorf("%w: %s", utils.ErrBarcodeNotFound, code)
+	}
+
+	if code == "" {
+		return nil, utils.ErrProductHasNoBarcode
+	}
+
+	encoded, err := barcode.Encode(code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrUnencodableBarcode, err)
+	}
+
+	return encoded, nil
+}
+
+// GetLabels menyiapkan label rak. Produk tanpa barcode atau dengan SKU yang tidak bisa
+// di-encode tetap dicetak, hanya tanpa barcode
+func (s *productService) GetLabels(body *dto.LabelRequest) ([]label.Label, error) {
+	products, err := s.repo.GetLabelProducts(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(products) == 0 {
+		return nil, utils.ErrProductNotFound
+	}
+
+	copies := max(body.Copies, 1)
+	labels := make([]label.Label, 0, len(products)*copies)
+	for _, product := range products {
+		l := label.Label{
+			Name:  product.Name,
+			Price: product.Price,
+		}
+
+		if code := labelCode(product); code != "" {
+			l.Barcode, _ = barcode.Encode(code)
+		}
+
+		for range copies {
+			labels = append(labels, l)
+		}
+	}
+
+	return labels, nil
+}
+
+// labelCode mengutamakan barcode EAN/UPC pertama, lalu SKU
+func labelCode(product *model.ProductCategory) string {
+	if len(product.Barcodes) > 0 {
+		return product.Barcodes[0]
+	}
+	if product.SKU != nil {
+		return *product.SKU
+	}
+	return ""
+}
+
+// optionalString mengubah string kosong menjadi nil supaya disimpan sebagai NULL
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/gofrs/uuid/v5"
+)
+
+type PromotionService interface {
+	GetPromotions(query *dto.PromotionQuery) ([]*model.Promotion, int, error)
+	GetPromotionByID(id string) (*model.Promotion, error)
+	CreatePromotion(body *dto.PromotionRequest) (*model.Promotion, error)
+	UpdatePromotionByID(id string, body *dto.PromotionRequest) (*model.Promotion, error)
+	DeletePromotionByID(id string) error
+}
+
+type promotionService struct {
+	repo repository.PromotionRepository
+}
+
+func NewPromotionService(repo repository.PromotionRepository) PromotionService {
+	return &promotionService{
+		repo: repo,
+	}
+}
+
+func (s *promotionService) GetPromotions(query *dto.PromotionQuery) ([]*model.Promotion, int, error) {
+	return s.repo.GetPromotions(query)
+}
+
+func (s *promotionService) GetPromotionByID(id string) (*model.Promotion, error) {
+	return s.repo.GetPromotionByID(id)
+}
+
+func (s *promotionService) CreatePromotion(body *dto.PromotionRequest) (*model.Promotion, error) {
+	promotion, err := toPromotion(body)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	promotion.ID = id
+
+	return s.repo.CreatePromotion(promotion)
+}
+
+func (s *promotionService) UpdatePromotionByID(id string, body *dto.PromotionRequest) (*model.Promotion, error) {
+	promotion, err := toPromotion(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdatePromotionByID(id, promotion)
+}
+
+func (s *promotionService) DeletePromotionByID(id string) error {
+	return s.repo.DeletePromotionByID(id)
+}
+
+// toPromotion memvalidasi kombinasi field sesuai tipe promo
+func toPromotion(body *dto.PromotionRequest) (*model.Promotion, error) {
+	if !slices.Contains(model.PromotionTypes, body.Type) {
+		return nil, fmt.Errorf("%w: unsupported type %q", utils.ErrInvalidPromotion, body.Type)
+	}
+
+	if !body.EndAt.After(body.StartAt) {
+		return nil, fmt.Errorf("%w: end_at must be after start_at", utils.ErrInvalidPromotion)
+	}
+
+	promotion := &model.Promotion{
+		Name:           body.Name,
+		Type:           body.Type,
+		Code:           body.Code,
+		Value:          body.Value,
+		BuyQuantity:    body.BuyQuantity,
+		GetQuantity:    body.GetQuantity,
+		BundleQuantity: body.BundleQuantity,
+		BundlePrice:    body.BundlePrice,
+		MinPurchase:    body.MinPurchase,
+		MaxDiscount:    body.MaxDiscount,
+		StartAt:        body.StartAt,
+		EndAt:          body.EndAt,
+		IsActive:       body.IsActive,
+	}
+
+	if promotion.IsCartLevel() {
+		if body.Code == "" {
+			return nil, fmt.Errorf("%w: code is required for cart promotion", utils.ErrInvalidPromotion)
+		}
+		if body.ProductID != "" {
+			return nil, fmt.Errorf("%w: cart promotion cannot target a product", utils.ErrInvalidPromotion)
+		}
+	} else {
+		if body.Code != "" {
+			return nil, fmt.Errorf("%w: code is only allowed for cart promotion", utils.ErrInvalidPromotion)
+		}
+
+		productID, err := uuid.FromString(body.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: product_id is required for item promotion", utils.ErrInvalidPromotion)
+		}
+		promotion.ProductID = &productID
+	}
+
+	switch body.Type {
+	case model.PromotionTypeItemPercentage, model.PromotionTypeCartPercentage:
+		if body.Value <= 0 || body.Value > 100 {
+			return nil, fmt.Errorf("%w: percentage value must be between 1 and 100", utils.ErrInvalidPromotion)
+		}
+	case model.PromotionTypeItemNominal, model.PromotionTypeCartNominal:
+		if body.Value <= 0 {
+			return nil, fmt.Errorf("%w: nominal value must be greater than 0", utils.ErrInvalidPromotion)
+		}
+	case model.PromotionTypeBuyXGetY:
+		if body.BuyQuantity <= 0 || body.GetQuantity <= 0 {
+			return nil, fmt.Errorf("%w: buy_quantity and get_quantity must be greater than 0", utils.ErrInvalidPromotion)
+		}
+	case model.PromotionTypeBundlePrice:
+		if body.BundleQuantity < 2 || body.BundlePrice <= 0 {
+			return nil, fmt.Errorf("%w: bundle_quantity must be at least 2 and bundle_price greater than 0", utils.ErrInvalidPromotion)
+		}
+	}
+
+	return promotion, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/gofrs/uuid/v5"
+)
+
+type PurchaseOrderService interface {
+	CreatePurchaseOrder(body *dto.PurchaseOrderRequest) (*model.PurchaseOrder, error)
+	GetPurchaseOrders(query *dto.PurchaseOrderQuery) ([]*model.PurchaseOrder, int, error)
+	GetPurchaseOrderByID(id string) (*model.PurchaseOrder, error)
+	UpdatePurchaseOrderByID(id string, body *dto.PurchaseOrderRequest) (*model.PurchaseOrder, error)
+	SendPurchaseOrder(id string) (*model.PurchaseOrder, error)
+	ReceivePurchaseOrder(id string, body *dto.GoodsReceiptRequest) (*model.PurchaseOrder, error)
+	ClosePurchaseOrder(id string) (*model.PurchaseOrder, error)
+}
+
+type purchaseOrderService struct {
+	repo repository.PurchaseOrderRepository
+}
+
+func NewPurchaseOrderService(repo repository.PurchaseOrderRepository) PurchaseOrderService {
+	return &purchaseOrderService{
+		repo: repo,
+	}
+}
+
+func (s *purchaseOrderService) CreatePurchaseOrder(body *dto.PurchaseOrderRequest) (*model.PurchaseOrder, error) {
+	order, err := toPurchaseOrder(body)
+	if err != nil {
+		return nil, err
+	}
+
+	order.ID, err = uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreatePurchaseOrder(order)
+}
+
+func (s *purchaseOrderService) GetPurchaseOrders(query *dto.PurchaseOrderQuery) ([]*model.PurchaseOrder, int, error) {
+	return s.repo.GetPurchaseOrders(query)
+}
+
+func (s *purchaseOrderService) GetPurchaseOrderByID(id string) (*model.PurchaseOrder, error) {
+	return s.repo.GetPurchaseOrderByID(id)
+}
+
+func (s *purchaseOrderService) UpdatePurchaseOrderByID(id string, body *dto.PurchaseOrderRequest) (*model.PurchaseOrder, error) {
+	order, err := toPurchaseOrder(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdatePurchaseOrderByID(id, order)
+}
+
+func (s *purchaseOrderService) SendPurchaseOrder(id string) (*model.PurchaseOrder, error) {
+	return s.repo.SendPurchaseOrder(id)
+}
+
+func (s *purchaseOrderService) ReceivePurchaseOrder(id string, body *dto.GoodsReceiptRequest) (*model.PurchaseOrder, error) {
+	receiptID, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &model.GoodsReceipt{
+		ID:         receiptID,
+		Note:       body.Note,
+		ReceivedBy: body.UserID,
+		Items:      make([]model.GoodsReceiptItem, 0, len(body.Items)),
+	}
+
+	seen := make(map[uuid.UUID]bool, len(body.Items))
+	for _, item := range body.Items {
+		if seen[item.ProductID] {
+			return nil, fmt.Errorf("%w: duplicate product %s", utils.ErrInvalidPurchaseOrderItem, item.ProductID)
+		}
+		seen[item.ProductID] = true
+
+		receipt.Items = append(receipt.Items, model.GoodsReceiptItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return s.repo.ReceivePurchaseOrder(id, receipt)
+}
+
+func (s *purchaseOrderService) ClosePurchaseOrder(id string) (*model.PurchaseOrder, error) {
+	return s.repo.ClosePurchaseOrder(id)
+}
+
+// toPurchaseOrder menolak produk ganda dan menghitung total biaya PO
+func toPurchaseOrder(body *dto.PurchaseOrderRequest) (*model.PurchaseOrder, error) {
+	order := &model.PurchaseOrder{
+		SupplierID: body.SupplierID,
+		Note:       body.Note,
+		CreatedBy:  body.UserID,
+		Items:      make([]model.PurchaseOrderItem, 0, len(body.Items)),
+	}
+
+	seen := make(map[uuid.UUID]bool, len(body.Items))
+	for _, item := range body.Items {
+		if seen[item.ProductID] {
+			return nil, fmt.Errorf("%w: duplicate product %s", utils.ErrInvalidPurchaseOrderItem, item.ProductID)
+		}
+		seen[item.ProductID] = true
+
+		itemID, err := uuid.NewV7()
+		if err != nil {
+			return nil, err
+		}
+
+		subtotal := int64(item.Quantity) * item.UnitCost
+		order.Items = append(order.Items, model.PurchaseOrderItem{
+			ID:        itemID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			UnitCost:  item.UnitCost,
+			Subtotal:  subtotal,
+		})
+		order.TotalCost += subtotal
+	}
+
+	return order, nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+)
+
+type RefundService interface {
+	VoidTransaction(transactionID string, body *dto.VoidRequest) (*model.Refund, error)
+	RefundTransaction(transactionID string, body *dto.RefundRequest) (*model.Refund, error)
+	GetRefundsByTransactionID(transactionID string) ([]*model.Refund, error)
+}
+
+type refundService struct {
+	repo repository.RefundRepository
+}
+
+func NewRefundService(repo repository.RefundRepository) RefundService {
+	return &refundService{
+		repo: repo,
+	}
+}
+
+func (s *refundService) VoidTransaction(transactionID string, body *dto.VoidRequest) (*model.Refund, error) {
+	return s.repo.VoidTransaction(transactionID, body)
+}
+
+func (s *refundService) RefundTransaction(transactionID string, body *dto.RefundRequest) (*model.Refund, error) {
+	return s.repo.RefundTransaction(transactionID, body)
+}
+
+func (s *refundService) GetRefundsByTransactionID(transactionID string) ([]*model.Refund, error) {
+	return s.repo.GetRefundsByTransactionID(transactionID)
+}
@@ -3,11 +3,15 @@ package service
 import (
 	"github.com/Muh-Sidik/kasir-api/internal/model"
 	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/auth"
 	"github.com/Muh-Sidik/kasir-api/internal/repository"
 )
 
 type ReportService interface {
 	GetReport(param *dto.ReportParam) (*model.TopProductReport, error)
+	GetShiftReport(actor *auth.Claims, shiftID string) (*model.ShiftReport, error)
+	GetMarginReport(param *dto.MarginReportParam) (*model.MarginReport, error)
+	GetModifierReport(param *dto.ModifierReportParam) ([]model.ModifierReportItem, error)
 }
 
 type reportService struct {
@@ -21,7 +25,7 @@ func NewReportService(reportRepo repository.ReportRepository) ReportService {
 }
 
 func (s *reportService) GetReport(param *dto.ReportParam) (*model.TopProductReport, error) {
-	if _, _, err := param.ParseDates(); err != nil {
+	if err := param.Validate(); err != nil {
 		return nil, err
 	}
 
@@ -32,3 +36,32 @@ func (s *reportService) GetReport(param *dto.ReportParam) (*model.TopProductRepo
 
 	return report, nil
 }
+
+func (s *reportService) GetShiftReport(actor *auth.Claims, shiftID string) (*model.ShiftReport, error) {
+	report, err := s.reportRepo.ShiftReport(shiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := canAccessShift(actor, &report.Shift); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *reportService) GetMarginReport(param *dto.MarginReportParam) (*model.MarginReport, error) {
+	if err := param.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.reportRepo.MarginReport(param)
+}
+
+func (s *reportService) GetModifierReport(param *dto.ModifierReportParam) ([]model.ModifierReportItem, error) {
+	if err := param.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.reportRepo.ModifierReport(param)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/auth"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/gofrs/uuid/v5"
+)
+
+type ShiftService interface {
+	OpenShift(actor *auth.Claims, body *dto.OpenShiftRequest) (*model.Shift, error)
+	CurrentShift(actor *auth.Claims) (*model.Shift, error)
+	GetShifts(query *dto.ShiftQuery) ([]*model.Shift, int, error)
+	GetShiftByID(actor *auth.Claims, id string) (*model.Shift, error)
+	AddCashMovement(actor *auth.Claims, id string, body *dto.CashMovementRequest) (*model.CashMovement, error)
+	CloseShift(actor *auth.Claims, id string, body *dto.CloseShiftRequest) (*model.Shift, error)
+}
+
+type shiftService struct {
+	repo repository.ShiftRepository
+}
+
+func NewShiftService(repo repository.ShiftRepository) ShiftService {
+	return &shiftService{
+		repo: repo,
+	}
+}
+
+// canAccessShift: kasir hanya boleh mengakses shift miliknya, manager ke atas semua shift
+func canAccessShift(actor *auth.Claims, shift *model.Shift) error {
+	if shift.CashierID.String() == actor.Subject || model.RoleAllows(actor.Role, model.RoleManager) {
+		return nil
+	}
+
+	return utils.ErrForbidden
+}
+
+func (s *shiftService) OpenShift(actor *auth.Claims, body *dto.OpenShiftRequest) (*model.Shift, error) {
+	cashierID, err := uuid.FromString(actor.Subject)
+	if err != nil {
+		return nil, utils.ErrUnauthenticated
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.OpenShift(&model.Shift{
+		ID:           id,
+		CashierID:    cashierID,
+		TerminalID:   body.TerminalID,
+		OpeningFloat: body.OpeningFloat,
+		OpeningNote:  body.Note,
+	})
+}
+
+func (s *shiftService) CurrentShift(actor *auth.Claims) (*model.Shift, error) {
+	return s.repo.GetOpenShift(actor.Subject)
+}
+
+func (s *shiftService) GetShifts(query *dto.ShiftQuery) ([]*model.Shift, int, error) {
+	return s.repo.GetShifts(query)
+}
+
+func (s *shiftService) GetShiftByID(actor *auth.Claims, id string) (*model.Shift, error) {
+	shift, err := s.repo.GetShiftByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := canAccessShift(actor, shift); err != nil {
+		return nil, err
+	}
+
+	return shift, nil
+}
+
+func (s *shiftService) AddCashMovement(actor *auth.Claims, id string, body *dto.CashMovementRequest) (*model.CashMovement, error) {
+	if _, err := s.GetShiftByID(actor, id); err != nil {
+		return nil, err
+	}
+
+	movementID, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	movement := &model.CashMovement{
+		ID:     movementID,
+		Type:   body.Type,
+		Amount: body.Amount,
+		Reason: body.Reason,
+	}
+	if createdBy, err := uuid.FromString(actor.Subject); err == nil {
+		movement.CreatedBy = &createdBy
+	}
+
+	return s.repo.AddCashMovement(id, movement)
+}
+
+func (s *shiftService) CloseShift(actor *auth.Claims, id string, body *dto.CloseShiftRequest) (*model.Shift, error) {
+	if _, err := s.GetShiftByID(actor, id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CloseShift(id, body)
+}
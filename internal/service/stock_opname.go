@@ -0,0 +1,61 @@
+package service
+
+import (
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/gofrs/uuid/v5"
+)
+
+type StockOpnameService interface {
+	CreateStockOpname(body *dto.StockOpnameRequest) (*model.StockOpname, error)
+	GetStockOpnames(query *dto.StockOpnameQuery) ([]*model.StockOpname, int, error)
+	GetStockOpnameByID(id string) (*model.StockOpname, error)
+	SubmitCounts(id string, body *dto.StockOpnameCountRequest) (*model.StockOpname, error)
+	PostStockOpname(id string, userID *uuid.UUID) (*model.StockOpname, error)
+	CancelStockOpname(id string, userID *uuid.UUID) (*model.StockOpname, error)
+}
+
+type stockOpnameService struct {
+	repo repository.StockOpnameRepository
+}
+
+func NewStockOpnameService(repo repository.StockOpnameRepository) StockOpnameService {
+	return &stockOpnameService{
+		repo: repo,
+	}
+}
+
+func (s *stockOpnameService) CreateStockOpname(body *dto.StockOpnameRequest) (*model.StockOpname, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateStockOpname(&model.StockOpname{
+		ID:         id,
+		CategoryID: body.CategoryID,
+		Note:       body.Note,
+		CreatedBy:  body.UserID,
+	})
+}
+
+func (s *stockOpnameService) GetStockOpnames(query *dto.StockOpnameQuery) ([]*model.StockOpname, int, error) {
+	return s.repo.GetStockOpnames(query)
+}
+
+func (s *stockOpnameService) GetStockOpnameByID(id string) (*model.StockOpname, error) {
+	return s.repo.GetStockOpnameByID(id)
+}
+
+func (s *stockOpnameService) SubmitCounts(id string, body *dto.StockOpnameCountRequest) (*model.StockOpname, error) {
+	return s.repo.SubmitCounts(id, body)
+}
+
+func (s *stockOpnameService) PostStockOpname(id string, userID *uuid.UUID) (*model.StockOpname, error) {
+	return s.repo.PostStockOpname(id, userID)
+}
+
+func (s *stockOpnameService) CancelStockOpname(id string, userID *uuid.UUID) (*model.StockOpname, error) {
+	return s.repo.CancelStockOpname(id, userID)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/gofrs/uuid/v5"
+)
+
+type SupplierService interface {
+	GetSuppliers(query *dto.SupplierQuery) ([]*model.Supplier, int, error)
+	GetSupplierByID(id string) (*model.Supplier, error)
+	CreateSupplier(body *dto.SupplierRequest) (*model.Supplier, error)
+	UpdateSupplierByID(id string, body *dto.SupplierRequest) (*model.Supplier, error)
+	DeleteSupplierByID(id string) error
+}
+
+type supplierService struct {
+	repo repository.SupplierRepository
+}
+
+func NewSupplierService(repo repository.SupplierRepository) SupplierService {
+	return &supplierService{
+		repo: repo,
+	}
+}
+
+func (s *supplierService) GetSuppliers(query *dto.SupplierQuery) ([]*model.Supplier, int, error) {
+	return s.repo.GetSuppliers(query)
+}
+
+func (s *supplierService) GetSupplierByID(id string) (*model.Supplier, error) {
+	return s.repo.GetSupplierByID(id)
+}
+
+func (s *supplierService) CreateSupplier(body *dto.SupplierRequest) (*model.Supplier, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	supplier := toSupplier(body)
+	supplier.ID = id
+
+	return s.repo.CreateSupplier(supplier)
+}
+
+func (s *supplierService) UpdateSupplierByID(id string, body *dto.SupplierRequest) (*model.Supplier, error) {
+	return s.repo.UpdateSupplierByID(id, toSupplier(body))
+}
+
+func (s *supplierService) DeleteSupplierByID(id string) error {
+	return s.repo.DeleteSupplierByID(id)
+}
+
+func toSupplier(body *dto.SupplierRequest) *model.Supplier {
+	return &model.Supplier{
+		Name:        body.Name,
+		ContactName: body.ContactName,
+		Phone:       body.Phone,
+		Email:       body.Email,
+		Address:     body.Address,
+	}
+}
@@ -1,13 +1,23 @@
 package service
 
 import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
 	"github.com/Muh-Sidik/kasir-api/internal/model"
 	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
 	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/bytedance/sonic"
 )
 
 type TransactionService interface {
-	CreateCheckout(items []dto.CheckoutItem) (*model.Transaction, error)
+	CreateCheckout(body *dto.CheckoutRequest, idempotencyKey string) (*model.Transaction, bool, error)
+	GetTransactions(query *dto.TransactionQuery) ([]*model.Transaction, int, error)
+	GetTransactionByID(id string) (*model.Transaction, error)
 }
 
 type transactionService struct {
@@ -20,6 +30,78 @@ func NewTransactionService(repo repository.TransactionRepository) TransactionSer
 	}
 }
 
-func (t *transactionService) CreateCheckout(items []dto.CheckoutItem) (*model.Transaction, error) {
-	return t.repo.CreateTransaction(items)
+// CreateCheckout membuat transaksi baru. Jika idempotencyKey diisi dan sudah pernah
+// dipakai dengan body yang sama, transaksi asli dikembalikan (replayed = true)
+// tanpa membuat transaksi dan mengurangi stok lagi. Key hanya berlaku untuk kasir yang sama.
+func (t *transactionService) CreateCheckout(body *dto.CheckoutRequest, idempotencyKey string) (*model.Transaction, bool, error) {
+	// checkout tanpa kasir selalu ditolak repository, key tidak perlu disimpan
+	if idempotencyKey == "" || body.CashierID == nil {
+		transaction, err := t.repo.CreateTransaction(body, nil)
+		return transaction, false, err
+	}
+
+	if len(idempotencyKey) > 255 {
+		return nil, false, fmt.Errorf("%w: key must not exceed 255 characters", utils.ErrInvalidIdempotencyKey)
+	}
+
+	fingerprint, err := checkoutFingerprint(body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := &model.IdempotencyKey{
+		CashierID:   *body.CashierID,
+		Key:         idempotencyKey,
+		RequestHash: fingerprint,
+	}
+
+	transaction, err := t.replay(key)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return transaction, err == nil, err
+	}
+
+	transaction, err = t.repo.CreateTransaction(body, key)
+	if errors.Is(err, utils.ErrIdempotencyKeyExists) {
+		// request paralel dengan key yang sama sudah lebih dulu commit
+		transaction, err = t.replay(key)
+		return transaction, err == nil, err
+	}
+
+	return transaction, false, err
+}
+
+func (t *transactionService) replay(key *model.IdempotencyKey) (*model.Transaction, error) {
+	record, err := t.repo.FindIdempotencyKey(key.CashierID, key.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.RequestHash != key.RequestHash {
+		return nil, utils.ErrIdempotencyKeyReused
+	}
+
+	var transaction model.Transaction
+	if err := sonic.Unmarshal(record.Response, &transaction); err != nil {
+		return nil, fmt.Errorf("unmarshal idempotent response failed: %w", err)
+	}
+
+	return &transaction, nil
+}
+
+func checkoutFingerprint(body *dto.CheckoutRequest) (string, error) {
+	raw, err := sonic.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (t *transactionService) GetTransactions(query *dto.TransactionQuery) ([]*model.Transaction, int, error) {
+	return t.repo.GetTransactions(query)
+}
+
+func (t *transactionService) GetTransactionByID(id string) (*model.Transaction, error) {
+	return t.repo.GetTransactionByID(id)
 }
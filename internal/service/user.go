@@ -0,0 +1,106 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Muh-Sidik/kasir-api/internal/model"
+	"github.com/Muh-Sidik/kasir-api/internal/model/dto"
+	"github.com/Muh-Sidik/kasir-api/internal/pkg/utils"
+	"github.com/Muh-Sidik/kasir-api/internal/repository"
+	"github.com/gofrs/uuid/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserService interface {
+	GetUsers(query *dto.UserQuery) ([]*model.User, int, error)
+	GetUserByID(id string) (*model.User, error)
+	CreateUser(body *dto.CreateUserRequest) (*model.User, error)
+	UpdateUserByID(actorID string, id string, body *dto.UpdateUserRequest) (*model.User, error)
+	EnsureAdmin(username, password string) (bool, error)
+}
+
+type userService struct {
+	repo repository.UserRepository
+}
+
+func NewUserService(repo repository.UserRepository) UserService {
+	return &userService{
+		repo: repo,
+	}
+}
+
+func (s *userService) GetUsers(query *dto.UserQuery) ([]*model.User, int, error) {
+	return s.repo.GetUsers(query)
+}
+
+func (s *userService) GetUserByID(id string) (*model.User, error) {
+	return s.repo.GetUserByID(id)
+}
+
+func (s *userService) CreateUser(body *dto.CreateUserRequest) (*model.User, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(body.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("hash password failed: %w", err)
+	}
+
+	return s.repo.CreateUser(&model.User{
+		ID:           id,
+		Username:     body.Username,
+		Name:         body.Name,
+		PasswordHash: string(hash),
+		Role:         body.Role,
+		IsActive:     true,
+	})
+}
+
+func (s *userService) UpdateUserByID(actorID string, id string, body *dto.UpdateUserRequest) (*model.User, error) {
+	// admin tidak boleh mengunci dirinya sendiri keluar dari manajemen user
+	if actorID == id && (body.Role != model.RoleAdmin || !body.IsActive) {
+		return nil, utils.ErrSelfLockout
+	}
+
+	user := &model.User{
+		Name:     body.Name,
+		Role:     body.Role,
+		IsActive: body.IsActive,
+	}
+
+	if body.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(body.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("hash password failed: %w", err)
+		}
+		user.PasswordHash = string(hash)
+	}
+
+	return s.repo.UpdateUserByID(id, user)
+}
+
+// EnsureAdmin membuat admin pertama jika tabel users masih kosong
+func (s *userService) EnsureAdmin(username, password string) (bool, error) {
+	total, err := s.repo.CountUsers()
+	if err != nil {
+		return false, err
+	}
+
+	if total > 0 || password == "" {
+		return false, nil
+	}
+
+	_, err = s.CreateUser(&dto.CreateUserRequest{
+		Username: username,
+		Name:     "Administrator",
+		Password: password,
+		Role:     model.RoleAdmin,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
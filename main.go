@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 
 	"github.com/Muh-Sidik/kasir-api/config"
 	"github.com/Muh-Sidik/kasir-api/database"
@@ -25,15 +27,45 @@ import (
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 
 // @BasePath /
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and the access token.
 func main() {
 	e := config.LoadConfig()
 
 	docs.SwaggerInfo.Host = e.APP_HOST + ":" + e.APP_PORT
 	docs.SwaggerInfo.Schemes = []string{"https", "http"}
 
+	dialect := database.DialectOf(e.DB_URL)
 	db := database.New(e)
 	defer db.Close()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(db, dialect, os.Args[2:]); err != nil {
+			log.Fatalf("error migrate: %v", err)
+		}
+		return
+	}
+
+	if err := e.EnsureJWTSecret(); err != nil {
+		log.Fatalf("error config: %v", err)
+	}
+
+	if e.AUTO_MIGRATE {
+		migrator, err := database.NewMigrator(db, dialect)
+		if err != nil {
+			log.Fatalf("error load migrations: %v", err)
+		}
+
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			log.Fatalf("error migrate: %v", err)
+		}
+		fmt.Printf("Database migrated, %d migration applied\n", len(applied))
+	}
+
 	mux := http.NewServeMux()
 
 	route.Setup(mux, e, db)